@@ -26,11 +26,26 @@ func main() {
 			controllers.NewContestController,
 			controllers.NewUserController,
 			controllers.NewSubmissionController,
+			controllers.NewAnnouncementController,
+			controllers.NewClarificationController,
+			controllers.NewDriveController,
+			controllers.NewInterviewController,
+			controllers.NewTeamController,
+			controllers.NewRoleController,
+			controllers.NewAuditController,
+			controllers.NewSuspensionController,
 			// Services
 			services.NewContestService,
 			services.NewUserService,
 			services.NewSubmissionService,
 			services.NewAdminService,
+			services.NewAnnouncementService,
+			services.NewClarificationService,
+			services.NewDriveService,
+			services.NewInterviewService,
+			services.NewTeamService,
+			services.NewAuditService,
+			services.NewSuspensionService,
 			// Server
 			internal.NewEchoServer,
 			// Stores
@@ -45,6 +60,10 @@ func main() {
 		fx.Invoke(routes.AddUserRoutes),
 		fx.Invoke(routes.AddContestRoutes),
 		fx.Invoke(routes.AddSubmissionRoutes),
+		fx.Invoke(routes.AddAnnouncementRoutes),
+		fx.Invoke(routes.AddClarificationRoutes),
+		fx.Invoke(routes.AddDriveRoutes),
+		fx.Invoke(routes.AddTeamRoutes),
 		// Admin routes
 		fx.Invoke(routes.AddAdminRoutes),
 
@@ -0,0 +1,367 @@
+package bundle
+
+import (
+	"app/internal/models"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+)
+
+// Version of the archive layout, bumped on incompatible changes
+const Version = 1
+
+const manifestFile = "contest.json"
+
+// Limits on the decompressed size of an archive, so that a small archive cannot expand
+// into more memory than the server has
+const (
+	maxFileSize  = 64 << 20  // Any one file
+	maxTotalSize = 256 << 20 // All files read from the archive together
+)
+
+// Bundle is a self-contained copy of a contest that can be moved between environments.
+//
+// The archive layout is:
+//
+//	contest.json                          contest metadata and problem manifest, including MCQ options, answers and marking
+//	problems/01/statement.md              problem statement
+//	problems/01/testcases/01.in, 01.out   test case files
+//	problems/01/assets/<name>             images and attachments referenced by the statement
+type Bundle struct {
+	Contest  models.Contest
+	Problems []Problem
+	Pools    []models.ContestPool
+	Sections []models.ContestSection
+}
+
+type Problem struct {
+	models.Problem // Description is stored in the archive as the statement
+	TestCases      []TestCase
+	AssetFiles     []Asset
+}
+
+type Asset struct {
+	Name        string
+	ContentType string
+	Data        string
+}
+
+type TestCase struct {
+	IsSample bool
+	Input    string
+	Output   string
+}
+
+type manifest struct {
+	Version  int               `json:"version"`
+	Contest  models.Contest    `json:"contest"`
+	Problems []problemManifest `json:"problems"`
+	Pools    []poolManifest    `json:"pools,omitempty"`
+	Sections []sectionManifest `json:"sections,omitempty"`
+}
+
+type sectionManifest struct {
+	Name         string `json:"name"`
+	Instructions string `json:"instructions,omitempty"`
+}
+
+type poolManifest struct {
+	Tag  string `json:"tag"`
+	Draw int    `json:"draw"`
+}
+
+type problemManifest struct {
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	Score          int                    `json:"score"`
+	Type           models.SubmissionType  `json:"type"`
+	Label          string                 `json:"label,omitempty"`
+	Section        int                    `json:"section,omitempty"` // 1-based index into the sections
+	Answer         []int                  `json:"answer,omitempty"`
+	MultipleChoice bool                   `json:"multiple_choice,omitempty"`
+	NegativeMarks  int                    `json:"negative_marks,omitempty"`
+	Pool           string                 `json:"pool,omitempty"`
+	PartialCredit  bool                   `json:"partial_credit,omitempty"`
+	Tags           []string               `json:"tags,omitempty"`
+	Difficulty     models.Difficulty      `json:"difficulty,omitempty"`
+	Options        []models.ProblemOption `json:"options,omitempty"`
+	Statement      string                 `json:"statement"`
+	TestCases      []testCaseManifest     `json:"test_cases,omitempty"`
+	Assets         []assetManifest        `json:"assets,omitempty"`
+}
+
+type assetManifest struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	File        string `json:"file"`
+}
+
+type testCaseManifest struct {
+	IsSample bool   `json:"is_sample"`
+	Input    string `json:"input"`
+	Output   string `json:"output"`
+}
+
+// Write encodes the bundle as a zip archive
+func Write(w io.Writer, b *Bundle) error {
+	zw := zip.NewWriter(w)
+
+	m := manifest{
+		Version:  Version,
+		Contest:  b.Contest,
+		Problems: make([]problemManifest, 0, len(b.Problems)),
+	}
+
+	sectionIndex := make(map[string]int, len(b.Sections))
+	for i, sec := range b.Sections {
+		sectionIndex[sec.ID] = i + 1
+		m.Sections = append(m.Sections, sectionManifest{Name: sec.Name, Instructions: sec.Instructions})
+	}
+
+	for i, p := range b.Problems {
+		dir := fmt.Sprintf("problems/%02d", i+1)
+		pm := problemManifest{
+			ID:             p.ID,
+			Name:           p.Name,
+			Score:          p.Score,
+			Type:           p.Type,
+			Label:          p.Label,
+			Section:        sectionIndex[p.SectionID],
+			Answer:         p.Answer,
+			MultipleChoice: p.MultipleChoice,
+			NegativeMarks:  p.NegativeMarks,
+			Pool:           p.Pool,
+			PartialCredit:  p.PartialCredit,
+			Tags:           p.Tags,
+			Difficulty:     p.Difficulty,
+			Options:        p.Options,
+			Statement:      path.Join(dir, "statement.md"),
+		}
+
+		if err := writeFile(zw, pm.Statement, p.Description); err != nil {
+			return err
+		}
+
+		for j, tc := range p.TestCases {
+			tm := testCaseManifest{
+				IsSample: tc.IsSample,
+				Input:    path.Join(dir, "testcases", fmt.Sprintf("%02d.in", j+1)),
+				Output:   path.Join(dir, "testcases", fmt.Sprintf("%02d.out", j+1)),
+			}
+
+			if err := writeFile(zw, tm.Input, tc.Input); err != nil {
+				return err
+			}
+			if err := writeFile(zw, tm.Output, tc.Output); err != nil {
+				return err
+			}
+
+			pm.TestCases = append(pm.TestCases, tm)
+		}
+
+		for _, a := range p.AssetFiles {
+			am := assetManifest{
+				Name:        a.Name,
+				ContentType: a.ContentType,
+				File:        path.Join(dir, "assets", a.Name),
+			}
+
+			if err := writeFile(zw, am.File, a.Data); err != nil {
+				return err
+			}
+
+			pm.Assets = append(pm.Assets, am)
+		}
+
+		m.Problems = append(m.Problems, pm)
+	}
+
+	for _, pool := range b.Pools {
+		m.Pools = append(m.Pools, poolManifest{Tag: pool.Tag, Draw: pool.Draw})
+	}
+
+	manifestJSON, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	if err := writeFile(zw, manifestFile, string(manifestJSON)); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// Read decodes a zip archive produced by Write
+func Read(data []byte) (*Bundle, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+
+	for _, f := range zr.File {
+		if f.UncompressedSize64 > maxFileSize {
+			return nil, fmt.Errorf("%s is larger than %d bytes", f.Name, maxFileSize)
+		}
+	}
+
+	r := &reader{zr: zr}
+
+	manifestJSON, err := r.readFile(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifest
+	if err := json.Unmarshal([]byte(manifestJSON), &m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+
+	if m.Version != Version {
+		return nil, fmt.Errorf("unsupported bundle version %d", m.Version)
+	}
+
+	b := &Bundle{
+		Contest:  m.Contest,
+		Problems: make([]Problem, 0, len(m.Problems)),
+	}
+
+	// Sections are identified by their index, the importer assigns real IDs
+	for i, sec := range m.Sections {
+		b.Sections = append(b.Sections, models.ContestSection{
+			ID:           strconv.Itoa(i + 1),
+			ContestID:    m.Contest.ID,
+			Name:         sec.Name,
+			Instructions: sec.Instructions,
+			Position:     i + 1,
+		})
+	}
+
+	for i, pm := range m.Problems {
+		if pm.Section < 0 || pm.Section > len(m.Sections) {
+			return nil, fmt.Errorf("problem %d refers to unknown section %d", i+1, pm.Section)
+		}
+		if !pm.Difficulty.IsValid() {
+			return nil, fmt.Errorf("problem %d has unknown difficulty %q", i+1, pm.Difficulty)
+		}
+
+		var sectionID string
+		if pm.Section > 0 {
+			sectionID = strconv.Itoa(pm.Section)
+		}
+
+		statement, err := r.readFile(pm.Statement)
+		if err != nil {
+			return nil, err
+		}
+
+		p := Problem{
+			Problem: models.Problem{
+				ID:             pm.ID,
+				ContestID:      m.Contest.ID,
+				Name:           pm.Name,
+				Description:    statement,
+				Score:          pm.Score,
+				Type:           pm.Type,
+				Position:       i + 1,
+				Label:          pm.Label,
+				SectionID:      sectionID,
+				Answer:         pm.Answer,
+				MultipleChoice: pm.MultipleChoice,
+				NegativeMarks:  pm.NegativeMarks,
+				Pool:           pm.Pool,
+				PartialCredit:  pm.PartialCredit,
+				Tags:           pm.Tags,
+				Difficulty:     pm.Difficulty,
+				Options:        pm.Options,
+			},
+		}
+
+		// Bundles written before options had weights give every option an equal share
+		for i := range p.Options {
+			p.Options[i].Weight = max(p.Options[i].Weight, 1)
+		}
+
+		for _, tm := range pm.TestCases {
+			input, err := r.readFile(tm.Input)
+			if err != nil {
+				return nil, err
+			}
+			output, err := r.readFile(tm.Output)
+			if err != nil {
+				return nil, err
+			}
+
+			p.TestCases = append(p.TestCases, TestCase{
+				IsSample: tm.IsSample,
+				Input:    input,
+				Output:   output,
+			})
+		}
+
+		for _, am := range pm.Assets {
+			data, err := r.readFile(am.File)
+			if err != nil {
+				return nil, err
+			}
+
+			p.AssetFiles = append(p.AssetFiles, Asset{
+				Name:        am.Name,
+				ContentType: am.ContentType,
+				Data:        data,
+			})
+		}
+
+		b.Problems = append(b.Problems, p)
+	}
+
+	for _, pool := range m.Pools {
+		b.Pools = append(b.Pools, models.ContestPool{ContestID: m.Contest.ID, Tag: pool.Tag, Draw: pool.Draw})
+	}
+
+	return b, nil
+}
+
+func writeFile(zw *zip.Writer, name string, contents string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+	if _, err := io.WriteString(f, contents); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// reader reads files from an archive, keeping count of the bytes read against maxTotalSize
+type reader struct {
+	zr    *zip.Reader
+	total int64
+}
+
+func (r *reader) readFile(name string) (string, error) {
+	f, err := r.zr.Open(name)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	// The sizes in the archive's headers are not trusted, reading stops past the limit
+	contents, err := io.ReadAll(io.LimitReader(f, maxFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", name, err)
+	}
+	if len(contents) > maxFileSize {
+		return "", fmt.Errorf("%s is larger than %d bytes", name, maxFileSize)
+	}
+
+	r.total += int64(len(contents))
+	if r.total > maxTotalSize {
+		return "", fmt.Errorf("archive contents are larger than %d bytes", maxTotalSize)
+	}
+
+	return string(contents), nil
+}
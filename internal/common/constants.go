@@ -2,3 +2,5 @@ package common
 
 const AUTH_USER_ID = "AUTH_USER_ID"
 const VALIDATED_REQUEST_BODY = "VALIDATED_REQUEST_BODY"
+const ADMIN_GRANTS = "ADMIN_GRANTS"
+const AUDIT_BEFORE = "AUDIT_BEFORE"
@@ -22,4 +22,82 @@ var (
 	InvalidYearError               = errors.New("invalid year")
 	KeyNotFoundError               = errors.New("key not found")
 	KeyAlreadyExistsError          = errors.New("key already exists")
+	DisqualificationReasonError    = errors.New("a reason is required to change disqualification")
+	InvalidEvidenceError           = errors.New("evidence must reference submissions of this user in this contest")
+	UserNotDisqualifiedError       = errors.New("user is not disqualified")
+	UserAlreadyDisqualifiedError   = errors.New("user is already disqualified")
+	AppealNotFoundError            = errors.New("appeal not found")
+	AppealAlreadyPendingError      = errors.New("an appeal is already pending")
+	AppealAlreadyResolvedError     = errors.New("appeal has already been resolved")
+	ContestHasNoProblemsError      = errors.New("contest must have at least one problem to be published")
+	ProblemsMissingTestCasesError  = errors.New("every code problem must have test cases to be published")
+	TestCaseNotFoundError          = errors.New("test case not found")
+	TemplateNotFoundError          = errors.New("template not found")
+	TemplateNotPublishableError    = errors.New("templates cannot be published, instantiate them instead")
+	InvalidBundleError             = errors.New("invalid contest bundle")
+	ImportConflictError            = errors.New("contest bundle conflicts with existing data")
+	ContestNotTimedError           = errors.New("contest does not have a personal time limit")
+	AttemptNotStartedError         = errors.New("attempt has not been started")
+	AttemptAlreadyStartedError     = errors.New("attempt has already been started")
+	AttemptExpiredError            = errors.New("attempt time is over")
+	OptionNotFoundError            = errors.New("option not found")
+	InvalidAnswerError             = errors.New("answer must reference options of the problem")
+	InvalidOptionError             = errors.New("selected options are not valid for this problem")
+	PoolNotFoundError              = errors.New("pool not found")
+	AssetNotFoundError             = errors.New("asset not found")
+	AssetAlreadyExistsError        = errors.New("an asset with this name already exists")
+	InvalidAssetNameError          = errors.New("asset name must be a plain file name")
+	SectionNotFoundError           = errors.New("section not found")
+	InvalidProblemOrderError       = errors.New("order must list every problem of the contest exactly once")
+	ProblemNotFoundError           = errors.New("problem not found")
+	ProblemInUseError              = errors.New("problem is attached to contests")
+	ProblemAlreadyAttachedError    = errors.New("problem is already attached to this contest")
+	ProblemSharedError             = errors.New("problem is used by other contests, edit it in the bank")
+	EditorialNotFoundError         = errors.New("editorial not found")
+	EditorialNotAvailableError     = errors.New("editorials are available once the contest has ended")
+	SolutionNotFoundError          = errors.New("reference solution not found")
+	AnnouncementNotFoundError      = errors.New("announcement not found")
+	ClarificationNotFoundError     = errors.New("clarification not found")
+	ClarificationRateLimitedError  = errors.New("too many clarifications, please wait before asking again")
+	NotEligibleError               = errors.New("not eligible for this contest")
+	InvalidUSNPatternError         = errors.New("usn_pattern must be a valid regular expression")
+	AccessEntryNotFoundError       = errors.New("user is not on the contest's access list")
+	InviteCodeNotFoundError        = errors.New("invalid invite code")
+	InviteCodeExpiredError         = errors.New("invite code has expired")
+	InviteCodeExhaustedError       = errors.New("invite code has been used up")
+	DriveNotFoundError             = errors.New("recruitment drive not found")
+	ContestInOtherDriveError       = errors.New("contest is already a round of another drive")
+	DuplicateRoundError            = errors.New("a contest can only be one round of a drive")
+	InterviewNotFoundError         = errors.New("interview not found")
+	CandidateNotShortlistedError   = errors.New("candidate was not shortlisted in the final round of the drive")
+	InterviewerNotAdminError       = errors.New("interviewers must be admins with a role that gives interview feedback")
+	InterviewerNotAssignedError    = errors.New("only interviewers assigned to the interview can give feedback")
+	InvalidTeamSizeError           = errors.New("team contests need 1 <= min_team_size <= max_team_size")
+	ContestNotTeamModeError        = errors.New("contest is not a team contest")
+	TeamRegistrationRequiredError  = errors.New("this is a team contest, register through your team")
+	TeamNotFoundError              = errors.New("team not found")
+	TeamNameTakenError             = errors.New("a team with this name already exists")
+	AlreadyInTeamError             = errors.New("user is already in a team for this contest")
+	TeamFullError                  = errors.New("team is full")
+	TeamSizeError                  = errors.New("team does not have an allowed number of members")
+	TeamAlreadyRegisteredError     = errors.New("team is already registered")
+	TeamNotRegisteredError         = errors.New("team is not registered")
+	NotTeamLeaderError             = errors.New("only the team leader can do this")
+	ContestFullError               = errors.New("contest is full")
+	VirtualNotAvailableError       = errors.New("virtual participation is only available for ended individual contests")
+	AlreadyParticipatedError       = errors.New("you took part in this contest")
+	VirtualNotStartedError         = errors.New("virtual participation has not been started")
+	RoleAlreadyGrantedError        = errors.New("user already has this role")
+	RoleGrantNotFoundError         = errors.New("role grant not found")
+	LastSuperAdminError            = errors.New("cannot revoke the last super admin")
+	SuperAdminScopedError          = errors.New("super_admin cannot be scoped to a contest")
+	USNTakenError                  = errors.New("USN is already in use by another user")
+	CannotBanSelfError             = errors.New("you cannot ban yourself")
+	RoleNotHigherError             = errors.New("you cannot manage a user with an equal or higher role")
+	AccountSuspendedError          = errors.New("your account is suspended")
+	InvalidStatusTransitionError   = errors.New("contest cannot move to this status")
+	ProblemOutOfScopeError         = errors.New("problem is used by contests outside your role's scope")
+	SuspensionNotFoundError        = errors.New("suspension not found")
+	SuspensionAlreadyLiftedError   = errors.New("suspension was already lifted")
+	InvalidSuspensionExpiryError   = errors.New("expires_at must be in the future")
 )
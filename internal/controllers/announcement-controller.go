@@ -0,0 +1,246 @@
+package controllers
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"app/internal/models/dto"
+	"app/internal/services"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// streamKeepAlive is how often an idle announcement stream sends a comment to keep proxies from closing it
+const streamKeepAlive = 30 * time.Second
+
+type AnnouncementController struct {
+	announcementService *services.AnnouncementService
+	contestService      *services.ContestService
+}
+
+func NewAnnouncementController(announcementService *services.AnnouncementService, contestService *services.ContestService) *AnnouncementController {
+	return &AnnouncementController{
+		announcementService: announcementService,
+		contestService:      contestService,
+	}
+}
+
+// checkRegistered responds with an error and returns false unless the user is registered in the contest
+func (ac *AnnouncementController) checkRegistered(ctx echo.Context, contestID string, userID string) (bool, error) {
+	contest, err := ac.contestService.GetContest(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return false, ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return false, ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": common.FetchContestFailedError.Error(),
+		})
+	}
+
+	if contest.IsRegistered == nil || !*contest.IsRegistered {
+		return false, ctx.JSON(http.StatusForbidden, map[string]string{
+			"error": common.UserNotRegisteredError.Error(),
+		})
+	}
+
+	return true, nil
+}
+
+func (ac *AnnouncementController) ListAnnouncements(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	if ok, err := ac.checkRegistered(ctx, contestID, userID); !ok {
+		return err
+	}
+
+	announcements, err := ac.announcementService.ListAnnouncements(ctx.Request().Context(), contestID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list announcements",
+		})
+	}
+
+	visible := make([]models.Announcement, 0, len(announcements))
+	for _, a := range announcements {
+		ok, err := ac.canSee(ctx.Request().Context(), contestID, userID, &a)
+		if err != nil {
+			return ctx.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "failed to list announcements",
+			})
+		}
+		if ok {
+			visible = append(visible, a)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, visible)
+}
+
+// canSee reports whether an announcement is for the user, announcements about a problem
+// are only for the users who were given it
+func (ac *AnnouncementController) canSee(ctx context.Context, contestID string, userID string, a *models.Announcement) (bool, error) {
+	if a.ProblemID == "" {
+		return true, nil
+	}
+
+	if err := ac.contestService.CheckProblemInSet(ctx, contestID, userID, a.ProblemID); err != nil {
+		if err == common.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// StreamAnnouncements sends the announcement events of a contest as server-sent events
+// until the client disconnects or the server shuts down. Each event is named after its type and
+// carries the announcement.
+func (ac *AnnouncementController) StreamAnnouncements(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	if ok, err := ac.checkRegistered(ctx, contestID, userID); !ok {
+		return err
+	}
+
+	events, unsubscribe := ac.announcementService.Subscribe(contestID)
+	defer unsubscribe()
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Request().Context().Done():
+			return nil
+		case <-ac.announcementService.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				// Fell behind, the client reconnects and reloads the announcements
+				return nil
+			}
+			visible, err := ac.canSee(ctx.Request().Context(), contestID, userID, &event.Announcement)
+			if err != nil {
+				// The client reconnects and reloads the announcements
+				return nil
+			}
+			if !visible {
+				continue
+			}
+			data, err := json.Marshal(event.Announcement)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", event.Announcement.ID, event.Type, data); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// Admin Handlers
+func (ac *AnnouncementController) HandleListAnnouncements(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+
+	announcements, err := ac.announcementService.ListAnnouncements(ctx.Request().Context(), contestID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list announcements",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, announcements)
+}
+
+func (ac *AnnouncementController) HandleCreateAnnouncement(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertAnnouncementRequest)
+
+	announcement, err := ac.announcementService.CreateAnnouncement(ctx.Request().Context(), contestID, adminID, req)
+	if err != nil {
+		switch err {
+		case common.ContestNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.ProblemNotFoundError:
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create announcement",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, announcement)
+}
+
+func (ac *AnnouncementController) HandleUpdateAnnouncement(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	announcementID := ctx.Param("announcementid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertAnnouncementRequest)
+
+	announcement, err := ac.announcementService.UpdateAnnouncement(ctx.Request().Context(), contestID, announcementID, req)
+	if err != nil {
+		switch err {
+		case common.AnnouncementNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.ProblemNotFoundError:
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update announcement",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, announcement)
+}
+
+func (ac *AnnouncementController) HandleDeleteAnnouncement(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	announcementID := ctx.Param("announcementid")
+
+	if err := ac.announcementService.DeleteAnnouncement(ctx.Request().Context(), contestID, announcementID); err != nil {
+		if err == common.AnnouncementNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete announcement",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":        "announcement deleted successfully",
+		"announcementID": announcementID,
+	})
+}
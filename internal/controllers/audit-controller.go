@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"app/internal/common"
+	"app/internal/models/dto"
+	"app/internal/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditController struct {
+	auditService *services.AuditService
+}
+
+func NewAuditController(auditService *services.AuditService) *AuditController {
+	return &AuditController{
+		auditService: auditService,
+	}
+}
+
+func (ac *AuditController) HandleListAuditLog(ctx echo.Context) error {
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.ListAuditLogRequest)
+
+	entries, err := ac.auditService.ListEntries(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list audit log",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"app/internal/common"
+	"app/internal/models/dto"
+	"app/internal/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ClarificationController struct {
+	clarificationService *services.ClarificationService
+}
+
+func NewClarificationController(clarificationService *services.ClarificationService) *ClarificationController {
+	return &ClarificationController{
+		clarificationService: clarificationService,
+	}
+}
+
+func (cc *ClarificationController) CreateClarification(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.CreateClarificationRequest)
+
+	clarification, err := cc.clarificationService.CreateClarification(ctx.Request().Context(), contestID, userID, req)
+	if err != nil {
+		switch err {
+		case common.ContestNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.ProblemNotFoundError:
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		case common.UserNotRegisteredError, common.ContestNotRunningError:
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		case common.ClarificationRateLimitedError:
+			return ctx.JSON(http.StatusTooManyRequests, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create clarification",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, clarification)
+}
+
+func (cc *ClarificationController) ListClarifications(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	clarifications, err := cc.clarificationService.ListUserClarifications(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		switch err {
+		case common.ContestNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.UserNotRegisteredError:
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list clarifications",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, clarifications)
+}
+
+// Admin Handlers
+func (cc *ClarificationController) HandleListClarifications(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.ListClarificationsRequest)
+
+	clarifications, err := cc.clarificationService.ListClarifications(ctx.Request().Context(), contestID, req.Status, req.Page)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list clarifications",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, clarifications)
+}
+
+func (cc *ClarificationController) HandleAnswerClarification(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	clarificationID := ctx.Param("clarificationid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.AnswerClarificationRequest)
+
+	clarification, err := cc.clarificationService.AnswerClarification(ctx.Request().Context(), contestID, clarificationID, adminID, req)
+	if err != nil {
+		if err == common.ClarificationNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to answer clarification",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, clarification)
+}
@@ -1,11 +1,15 @@
 package controllers
 
 import (
+	"app/internal/bundle"
 	"app/internal/common"
 	"app/internal/models"
 	"app/internal/models/dto"
 	"app/internal/services"
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -14,6 +18,12 @@ import (
 	gonanoid "github.com/matoous/go-nanoid/v2"
 )
 
+// maxBundleSize is the largest contest bundle accepted for import
+const maxBundleSize = 64 << 20
+
+// maxAssetSize is the largest problem asset accepted for upload
+const maxAssetSize = 10 << 20
+
 type ContestController struct {
 	contestService *services.ContestService
 }
@@ -29,14 +39,21 @@ func (cc *ContestController) ModifyRegistration(ctx echo.Context) error {
 	userID := ctx.Get(common.AUTH_USER_ID).(string)
 	reqBody := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.ModifyRegistrationRequest)
 
-	if err := cc.contestService.ModifyRegistration(ctx.Request().Context(), contestID, userID, reqBody.Action); err != nil {
+	waitlist, err := cc.contestService.ModifyRegistration(ctx.Request().Context(), contestID, userID, reqBody.Action, reqBody.InviteCode)
+	if err != nil {
 		if err == common.ContestRegistrationClosedError ||
-			err == common.InvalidYearError {
+			err == common.InvalidYearError ||
+			err == common.AttemptAlreadyStartedError ||
+			err == common.InviteCodeExpiredError ||
+			err == common.InviteCodeExhaustedError ||
+			err == common.TeamRegistrationRequiredError ||
+			errors.Is(err, common.NotEligibleError) {
 			return ctx.JSON(http.StatusForbidden, map[string]string{
 				"error": err.Error(),
 			})
 		} else if err == common.ContestNotFoundError ||
-			err == common.UserNotFoundError {
+			err == common.UserNotFoundError ||
+			err == common.InviteCodeNotFoundError {
 			return ctx.JSON(http.StatusNotFound, map[string]string{
 				"error": err.Error(),
 			})
@@ -50,6 +67,10 @@ func (cc *ContestController) ModifyRegistration(ctx echo.Context) error {
 		})
 	}
 
+	if waitlist != nil {
+		return ctx.JSON(http.StatusAccepted, waitlist)
+	}
+
 	return ctx.NoContent(http.StatusOK)
 }
 
@@ -68,7 +89,244 @@ func (cc *ContestController) ListContests(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, contests)
 }
 
+func (cc *ContestController) ListPastContests(ctx echo.Context) error {
+	pageStr := ctx.QueryParam("page")
+
+	page, err := strconv.Atoi(pageStr)
+	if err != nil {
+		page = 0
+	}
+
+	contests, err := cc.contestService.ListPastContests(ctx.Request().Context(), page)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to list contests"})
+	}
+	return ctx.JSON(http.StatusOK, contests)
+}
+
 // Admin Handlers
+func (cc *ContestController) HandleListContests(ctx echo.Context) error {
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.AdminListContestsRequest)
+
+	contests, err := cc.contestService.ListAllContests(ctx.Request().Context(), req.Page, req.Status)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to list contests"})
+	}
+	return ctx.JSON(http.StatusOK, contests)
+}
+
+func (cc *ContestController) HandleListWaitlist(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+
+	waitlist, err := cc.contestService.ListWaitlist(ctx.Request().Context(), contestID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list waitlist",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, waitlist)
+}
+
+func (cc *ContestController) HandleListAccess(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+
+	entries, err := cc.contestService.ListAccess(ctx.Request().Context(), contestID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list access list",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, entries)
+}
+
+func (cc *ContestController) HandleSetAccess(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	userID := ctx.Param("userid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.SetAccessRequest)
+
+	entry, err := cc.contestService.SetAccess(ctx.Request().Context(), contestID, userID, adminID, req.Access)
+	if err != nil {
+		if err == common.ContestNotFoundError || err == common.UserNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update access list",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, entry)
+}
+
+func (cc *ContestController) HandleDeleteAccess(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	userID := ctx.Param("userid")
+
+	if err := cc.contestService.DeleteAccess(ctx.Request().Context(), contestID, userID); err != nil {
+		if err == common.AccessEntryNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update access list",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "access entry deleted successfully",
+		"userID":  userID,
+	})
+}
+
+func (cc *ContestController) HandleListInviteCodes(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+
+	codes, err := cc.contestService.ListInviteCodes(ctx.Request().Context(), contestID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list invite codes",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, codes)
+}
+
+func (cc *ContestController) HandleCreateInviteCode(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.CreateInviteCodeRequest)
+
+	code, err := cc.contestService.CreateInviteCode(ctx.Request().Context(), contestID, adminID, req.MaxUses, req.ExpiresAt)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create invite code",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, code)
+}
+
+func (cc *ContestController) HandleDeleteInviteCode(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	code := ctx.Param("code")
+
+	if err := cc.contestService.DeleteInviteCode(ctx.Request().Context(), contestID, code); err != nil {
+		if err == common.InviteCodeNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete invite code",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "invite code deleted successfully",
+		"code":    code,
+	})
+}
+
+func (cc *ContestController) HandleInviteShortlisted(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.InviteShortlistedRequest)
+
+	invited, err := cc.contestService.InviteShortlisted(ctx.Request().Context(), contestID, req.SourceContestID, adminID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to invite shortlisted users",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]int{
+		"invited": invited,
+	})
+}
+
+func (cc *ContestController) HandleGetContest(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+
+	contest, err := cc.contestService.GetContestDetails(ctx.Request().Context(), contestID)
+	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return ctx.NoContent(http.StatusNotFound)
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": common.FetchContestFailedError.Error(),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, contest)
+}
+
+func (cc *ContestController) HandleUpdateContestStatus(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpdateContestStatusRequest)
+
+	if before, err := cc.contestService.GetContestDetails(ctx.Request().Context(), contestID); err == nil {
+		ctx.Set(common.AUDIT_BEFORE, before)
+	}
+
+	err := cc.contestService.UpdateContestStatus(ctx.Request().Context(), contestID, req.Status)
+	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if errors.Is(err, common.ContestHasNoProblemsError) ||
+			errors.Is(err, common.ProblemsMissingTestCasesError) ||
+			errors.Is(err, common.TemplateNotPublishableError) {
+			return ctx.JSON(http.StatusUnprocessableEntity, map[string]string{
+				"error": err.Error(),
+			})
+		} else if errors.Is(err, common.InvalidStatusTransitionError) {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update contest status",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":   "contest status updated successfully",
+		"contestID": contestID,
+		"status":    string(req.Status),
+	})
+}
+
 func (cc *ContestController) HandleCreateContest(ctx echo.Context) error {
 	request := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertContestRequest)
 	if request.Name == "" || request.StartTime == 0 || request.EndTime == 0 {
@@ -91,9 +349,27 @@ func (cc *ContestController) HandleCreateContest(ctx echo.Context) error {
 		StartTime:             request.StartTime,
 		EndTime:               request.EndTime,
 		EligibleTo:            request.EligibleTo,
+		Duration:              request.Duration,
+		ClampScore:            request.ClampScore,
+		ShuffleProblems:       request.ShuffleProblems,
+		ShuffleOptions:        request.ShuffleOptions,
+		PracticeMode:          request.PracticeMode,
+		MaxParticipants:       request.MaxParticipants,
+		Departments:           request.Departments,
+		USNPattern:            request.USNPattern,
+		InviteOnly:            request.InviteOnly,
+		Private:               request.Private,
+		TeamMode:              request.TeamMode,
+		MinTeamSize:           request.MinTeamSize,
+		MaxTeamSize:           request.MaxTeamSize,
 	}
 	createdContest, err := cc.contestService.CreateContest(ctx.Request().Context(), &newContest)
 	if err != nil {
+		if err == common.InvalidUSNPatternError || err == common.InvalidTeamSizeError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "failed to create contest",
 		})
@@ -112,13 +388,14 @@ func (cc *ContestController) HandleUpdateContest(ctx echo.Context) error {
 
 	// Verify contest exists
 	id := ctx.Param("id")
-	_, err := cc.contestService.GetContest(ctx.Request().Context(), id, "")
+	before, err := cc.contestService.GetContestDetails(ctx.Request().Context(), id)
 	if err != nil {
 		if errors.Is(err, common.ContestNotFoundError) {
 			return ctx.NoContent(http.StatusNotFound)
 		}
 		return ctx.NoContent(http.StatusInternalServerError)
 	}
+	ctx.Set(common.AUDIT_BEFORE, before)
 
 	contestToUpdate := models.Contest{
 		ID:                    id,
@@ -129,9 +406,27 @@ func (cc *ContestController) HandleUpdateContest(ctx echo.Context) error {
 		StartTime:             req.StartTime,
 		EndTime:               req.EndTime,
 		EligibleTo:            req.EligibleTo,
+		Duration:              req.Duration,
+		ClampScore:            req.ClampScore,
+		ShuffleProblems:       req.ShuffleProblems,
+		ShuffleOptions:        req.ShuffleOptions,
+		PracticeMode:          req.PracticeMode,
+		MaxParticipants:       req.MaxParticipants,
+		Departments:           req.Departments,
+		USNPattern:            req.USNPattern,
+		InviteOnly:            req.InviteOnly,
+		Private:               req.Private,
+		TeamMode:              req.TeamMode,
+		MinTeamSize:           req.MinTeamSize,
+		MaxTeamSize:           req.MaxTeamSize,
 	}
 	updatedContest, err := cc.contestService.UpdateContest(ctx.Request().Context(), &contestToUpdate)
 	if err != nil {
+		if err == common.InvalidUSNPatternError || err == common.InvalidTeamSizeError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "failed to update contest",
 		})
@@ -140,119 +435,1177 @@ func (cc *ContestController) HandleUpdateContest(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, updatedContest)
 }
 
-func (cc *ContestController) HandleDeleteContest(ctx echo.Context) error {
-
+func (cc *ContestController) HandleCloneContest(ctx echo.Context) error {
 	contestID := ctx.Param("id")
-	if contestID == "" {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "contest ID is required",
-		})
-	}
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.CloneContestRequest)
 
-	err := cc.contestService.DeleteContest(ctx.Request().Context(), contestID)
+	contest, err := cc.contestService.CloneContest(ctx.Request().Context(), contestID, req)
 	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return ctx.NoContent(http.StatusNotFound)
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to delete contest",
+			"error": "failed to clone contest",
 		})
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]string{
-		"message":   "contest deleted successfully",
-		"contestID": contestID,
-	})
+	return ctx.JSON(http.StatusCreated, contest)
 }
 
-func (cc *ContestController) HandleCreateProblem(ctx echo.Context) error {
+func (cc *ContestController) HandleSaveTemplate(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.SaveTemplateRequest)
 
-	contestID := ctx.Param("contestid")
-	if contestID == "" {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "contest ID is required",
+	template, err := cc.contestService.SaveAsTemplate(ctx.Request().Context(), contestID, req.Name)
+	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return ctx.NoContent(http.StatusNotFound)
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to save template",
 		})
 	}
 
-	var newProblem models.Problem
-	if err := ctx.Bind(&newProblem); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
-		})
+	return ctx.JSON(http.StatusCreated, template)
+}
+
+func (cc *ContestController) HandleListTemplates(ctx echo.Context) error {
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil {
+		page = 0
 	}
 
-	if newProblem.Name == "" || newProblem.Score <= 0 || newProblem.Type == "" {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "name, score, and type are required fields",
-		})
+	templates, err := cc.contestService.ListTemplates(ctx.Request().Context(), page)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to list templates"})
 	}
+	return ctx.JSON(http.StatusOK, templates)
+}
 
-	newProblem.ContestID = contestID
+func (cc *ContestController) HandleInstantiateTemplate(ctx echo.Context) error {
+	templateID := ctx.Param("id")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.InstantiateTemplateRequest)
 
-	createdProblem, err := cc.contestService.CreateProblem(ctx.Request().Context(), &newProblem)
+	contest, err := cc.contestService.InstantiateTemplate(ctx.Request().Context(), templateID, req)
 	if err != nil {
+		if errors.Is(err, common.TemplateNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to create problem",
+			"error": "failed to instantiate template",
 		})
 	}
 
-	return ctx.JSON(http.StatusCreated, createdProblem)
+	return ctx.JSON(http.StatusCreated, contest)
 }
 
-func (cc *ContestController) HandleUpdateProblem(ctx echo.Context) error {
+func (cc *ContestController) HandleExportContest(ctx echo.Context) error {
+	contestID := ctx.Param("id")
 
-	contestID := ctx.Param("contestid")
-	problemID := ctx.Param("problemid")
-	if contestID == "" || problemID == "" {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "contest ID and problem ID are required",
+	b, err := cc.contestService.ExportContest(ctx.Request().Context(), contestID)
+	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return ctx.NoContent(http.StatusNotFound)
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to export contest",
 		})
 	}
 
-	var problemToUpdate models.Problem
-	if err := ctx.Bind(&problemToUpdate); err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid request body",
+	var buf bytes.Buffer
+	if err := bundle.Write(&buf, b); err != nil {
+		log.Errorf("failed to write bundle for contest %s: %v", contestID, err)
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to export contest",
 		})
 	}
 
-	if problemToUpdate.Name == "" || problemToUpdate.Score <= 0 || problemToUpdate.Type == "" {
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", contestID+".zip"))
+	return ctx.Blob(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+func (cc *ContestController) HandleImportContest(ctx echo.Context) error {
+	dryRun, _ := strconv.ParseBool(ctx.QueryParam("dry_run"))
+
+	fileHeader, err := ctx.FormFile("bundle")
+	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "name, score, and type are required fields",
+			"error": "bundle file is required",
+		})
+	}
+
+	if fileHeader.Size > maxBundleSize {
+		return ctx.JSON(http.StatusRequestEntityTooLarge, map[string]string{
+			"error": "bundle is too large",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ctx.NoContent(http.StatusInternalServerError)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return ctx.NoContent(http.StatusInternalServerError)
+	}
+
+	b, err := bundle.Read(data)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("%s: %v", common.InvalidBundleError.Error(), err),
+		})
+	}
+
+	res, err := cc.contestService.ImportContest(ctx.Request().Context(), b, dryRun)
+	if err != nil {
+		if errors.Is(err, common.ImportConflictError) {
+			return ctx.JSON(http.StatusConflict, res)
+		} else if errors.Is(err, common.InvalidBundleError) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to import contest",
+		})
+	}
+
+	if dryRun {
+		return ctx.JSON(http.StatusOK, res)
+	}
+	return ctx.JSON(http.StatusCreated, res)
+}
+
+func (cc *ContestController) HandleDeleteContest(ctx echo.Context) error {
+
+	contestID := ctx.Param("id")
+	if contestID == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "contest ID is required",
+		})
+	}
+
+	if before, err := cc.contestService.GetContestDetails(ctx.Request().Context(), contestID); err == nil {
+		ctx.Set(common.AUDIT_BEFORE, before)
+	}
+
+	err := cc.contestService.DeleteContest(ctx.Request().Context(), contestID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete contest",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":   "contest deleted successfully",
+		"contestID": contestID,
+	})
+}
+
+func (cc *ContestController) HandleCreateProblem(ctx echo.Context) error {
+
+	contestID := ctx.Param("contestid")
+	if contestID == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "contest ID is required",
+		})
+	}
+
+	var newProblem models.Problem
+	if err := ctx.Bind(&newProblem); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	if newProblem.Name == "" || newProblem.Score <= 0 || newProblem.Type == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name, score, and type are required fields",
+		})
+	}
+
+	if newProblem.NegativeMarks < 0 {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "negative_marks must not be negative",
+		})
+	}
+
+	if !newProblem.Difficulty.IsValid() {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "difficulty must be easy, medium or hard",
+		})
+	}
+
+	newProblem.ContestID = contestID
+
+	createdProblem, err := cc.contestService.CreateProblem(ctx.Request().Context(), &newProblem)
+	if err != nil {
+		if err == common.InvalidAnswerError || err == common.SectionNotFoundError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create problem",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, createdProblem)
+}
+
+func (cc *ContestController) HandleUpdateProblem(ctx echo.Context) error {
+
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	if contestID == "" || problemID == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "contest ID and problem ID are required",
+		})
+	}
+
+	var problemToUpdate models.Problem
+	if err := ctx.Bind(&problemToUpdate); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	if problemToUpdate.Name == "" || problemToUpdate.Score <= 0 || problemToUpdate.Type == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name, score, and type are required fields",
+		})
+	}
+
+	if problemToUpdate.NegativeMarks < 0 {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "negative_marks must not be negative",
 		})
 	}
 
 	problemToUpdate.ContestID = contestID
 	problemToUpdate.ID = problemID
 
-	updatedProblem, err := cc.contestService.UpdateProblem(ctx.Request().Context(), &problemToUpdate)
+	if before, err := cc.contestService.GetProblemDetails(ctx.Request().Context(), contestID, problemID); err == nil {
+		ctx.Set(common.AUDIT_BEFORE, before)
+	}
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	updatedProblem, err := cc.contestService.UpdateProblem(ctx.Request().Context(), &problemToUpdate, grants)
+	if err != nil {
+		if err == common.InvalidAnswerError || err == common.SectionNotFoundError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if err == common.ProblemNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if err == common.ProblemSharedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update problem",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, updatedProblem)
+}
+
+func (cc *ContestController) HandleDeleteProblem(ctx echo.Context) error {
+
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	if contestID == "" || problemID == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "contest ID and problem ID are required",
+		})
+	}
+
+	if before, err := cc.contestService.GetProblemDetails(ctx.Request().Context(), contestID, problemID); err == nil {
+		ctx.Set(common.AUDIT_BEFORE, before)
+	}
+
+	err := cc.contestService.DeleteProblem(ctx.Request().Context(), contestID, problemID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete problem",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":   "problem removed from contest",
+		"contestID": contestID,
+		"problemID": problemID,
+	})
+}
+
+func (cc *ContestController) HandleListOptions(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+
+	options, err := cc.contestService.ListOptions(ctx.Request().Context(), contestID, problemID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list options",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, options)
+}
+
+func (cc *ContestController) HandleCreateOption(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertOptionRequest)
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	option, err := cc.contestService.CreateOption(ctx.Request().Context(), contestID, problemID, req, grants)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.ProblemSharedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create option",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, option)
+}
+
+func (cc *ContestController) HandleUpdateOption(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertOptionRequest)
+
+	optionID, err := strconv.Atoi(ctx.Param("optionid"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid option ID",
+		})
+	}
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	option, err := cc.contestService.UpdateOption(ctx.Request().Context(), contestID, problemID, optionID, req, grants)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.OptionNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemSharedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update option",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, option)
+}
+
+func (cc *ContestController) HandleDeleteOption(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+
+	optionID, err := strconv.Atoi(ctx.Param("optionid"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid option ID",
+		})
+	}
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	err = cc.contestService.DeleteOption(ctx.Request().Context(), contestID, problemID, optionID, grants)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.OptionNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemSharedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete option",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"message":   "option deleted successfully",
+		"problemID": problemID,
+		"optionID":  optionID,
+	})
+}
+
+func (cc *ContestController) HandleReorderProblems(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.ReorderProblemsRequest)
+
+	problems, err := cc.contestService.ReorderProblems(ctx.Request().Context(), contestID, req)
+	if err != nil {
+		if err == common.InvalidProblemOrderError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to reorder problems",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, problems)
+}
+
+func (cc *ContestController) HandleAttachProblem(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.AttachProblemRequest)
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	problem, err := cc.contestService.AttachProblem(ctx.Request().Context(), contestID, req, grants)
+	if err != nil {
+		switch err {
+		case common.ContestNotFoundError, common.ProblemNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.ProblemOutOfScopeError:
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		case common.SectionNotFoundError:
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		case common.ProblemAlreadyAttachedError:
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to attach problem",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, problem)
+}
+
+// Problem Bank Handlers
+func (cc *ContestController) HandleListBankProblems(ctx echo.Context) error {
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.ListBankProblemsRequest)
+
+	problems, err := cc.contestService.ListBankProblems(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list problems",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, problems)
+}
+
+func (cc *ContestController) HandleGetBankProblem(ctx echo.Context) error {
+	problemID := ctx.Param("problemid")
+
+	problem, err := cc.contestService.GetBankProblem(ctx.Request().Context(), problemID)
+	if err != nil {
+		if err == common.ProblemNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch problem",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, problem)
+}
+
+func (cc *ContestController) HandleCreateBankProblem(ctx echo.Context) error {
+	var newProblem models.Problem
+	if err := ctx.Bind(&newProblem); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	if newProblem.Name == "" || newProblem.Score <= 0 || newProblem.Type == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name, score, and type are required fields",
+		})
+	}
+
+	if newProblem.NegativeMarks < 0 {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "negative_marks must not be negative",
+		})
+	}
+
+	if !newProblem.Difficulty.IsValid() {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "difficulty must be easy, medium or hard",
+		})
+	}
+
+	createdProblem, err := cc.contestService.CreateBankProblem(ctx.Request().Context(), &newProblem)
+	if err != nil {
+		if err == common.InvalidAnswerError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create problem",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, createdProblem)
+}
+
+func (cc *ContestController) HandleUpdateBankProblem(ctx echo.Context) error {
+	problemID := ctx.Param("problemid")
+
+	var problemToUpdate models.Problem
+	if err := ctx.Bind(&problemToUpdate); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+
+	if problemToUpdate.Name == "" || problemToUpdate.Score <= 0 || problemToUpdate.Type == "" {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name, score, and type are required fields",
+		})
+	}
+
+	if problemToUpdate.NegativeMarks < 0 {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "negative_marks must not be negative",
+		})
+	}
+
+	if !problemToUpdate.Difficulty.IsValid() {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "difficulty must be easy, medium or hard",
+		})
+	}
+
+	problemToUpdate.ID = problemID
+	problemToUpdate.ContestID = ""
+
+	updatedProblem, err := cc.contestService.UpdateBankProblem(ctx.Request().Context(), &problemToUpdate)
+	if err != nil {
+		switch err {
+		case common.ProblemNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.InvalidAnswerError:
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update problem",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, updatedProblem)
+}
+
+func (cc *ContestController) HandleDeleteBankProblem(ctx echo.Context) error {
+	problemID := ctx.Param("problemid")
+
+	if err := cc.contestService.DeleteBankProblem(ctx.Request().Context(), problemID); err != nil {
+		switch err {
+		case common.ProblemNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.ProblemInUseError:
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete problem",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":   "problem deleted successfully",
+		"problemID": problemID,
+	})
+}
+
+func (cc *ContestController) HandleListSections(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+
+	sections, err := cc.contestService.ListSections(ctx.Request().Context(), contestID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list sections",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, sections)
+}
+
+func (cc *ContestController) HandleCreateSection(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertSectionRequest)
+
+	section, err := cc.contestService.CreateSection(ctx.Request().Context(), contestID, req)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create section",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, section)
+}
+
+func (cc *ContestController) HandleUpdateSection(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	sectionID := ctx.Param("sectionid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertSectionRequest)
+
+	section, err := cc.contestService.UpdateSection(ctx.Request().Context(), contestID, sectionID, req)
+	if err != nil {
+		if err == common.SectionNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update section",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, section)
+}
+
+func (cc *ContestController) HandleDeleteSection(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	sectionID := ctx.Param("sectionid")
+
+	err := cc.contestService.DeleteSection(ctx.Request().Context(), contestID, sectionID)
+	if err != nil {
+		if err == common.SectionNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete section",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":   "section deleted successfully",
+		"sectionID": sectionID,
+	})
+}
+
+func (cc *ContestController) HandleListPools(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+
+	pools, err := cc.contestService.ListPools(ctx.Request().Context(), contestID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list pools",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, pools)
+}
+
+func (cc *ContestController) HandleUpsertPool(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	tag := ctx.Param("tag")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertPoolRequest)
+
+	pool, err := cc.contestService.UpsertPool(ctx.Request().Context(), contestID, tag, req.Draw)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to save pool",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, pool)
+}
+
+func (cc *ContestController) HandleDeletePool(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	tag := ctx.Param("tag")
+
+	err := cc.contestService.DeletePool(ctx.Request().Context(), contestID, tag)
+	if err != nil {
+		if err == common.PoolNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete pool",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "pool deleted successfully",
+		"tag":     tag,
+	})
+}
+
+func (cc *ContestController) HandleGetProblemSet(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	userID := ctx.Param("userid")
+
+	set, err := cc.contestService.GetUserProblemSet(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get problem set",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, set)
+}
+
+func (cc *ContestController) HandleCreateTestCase(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.CreateTestCaseRequest)
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	testCase, err := cc.contestService.CreateTestCase(ctx.Request().Context(), contestID, problemID, req, grants)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.ProblemSharedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create test case",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, testCase)
+}
+
+func (cc *ContestController) HandleGetEditorial(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+
+	editorial, err := cc.contestService.GetEditorial(ctx.Request().Context(), contestID, problemID)
+	if err != nil {
+		if err == common.EditorialNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get editorial",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, editorial)
+}
+
+func (cc *ContestController) HandleUpsertEditorial(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertEditorialRequest)
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	editorial, err := cc.contestService.UpsertEditorial(ctx.Request().Context(), contestID, problemID, req.Content, grants)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to save editorial",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, editorial)
+}
+
+func (cc *ContestController) HandleDeleteEditorial(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	if err := cc.contestService.DeleteEditorial(ctx.Request().Context(), contestID, problemID, grants); err != nil {
+		if err == common.EditorialNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete editorial",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":   "editorial deleted successfully",
+		"problemID": problemID,
+	})
+}
+
+func (cc *ContestController) HandleListSolutions(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+
+	solutions, err := cc.contestService.ListSolutions(ctx.Request().Context(), contestID, problemID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list solutions",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, solutions)
+}
+
+func (cc *ContestController) HandleCreateSolution(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.CreateSolutionRequest)
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	solution, err := cc.contestService.CreateSolution(ctx.Request().Context(), contestID, problemID, req, grants)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create solution",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, solution)
+}
+
+func (cc *ContestController) HandleDeleteSolution(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	solutionID := ctx.Param("solutionid")
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	if err := cc.contestService.DeleteSolution(ctx.Request().Context(), contestID, problemID, solutionID, grants); err != nil {
+		if err == common.SolutionNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete solution",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":    "solution deleted successfully",
+		"solutionID": solutionID,
+	})
+}
+
+func (cc *ContestController) HandleListTestCases(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+
+	testCases, err := cc.contestService.ListTestCases(ctx.Request().Context(), contestID, problemID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list test cases",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, testCases)
+}
+
+func (cc *ContestController) HandleDeleteTestCase(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+	testCaseID := ctx.Param("testcaseid")
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	err := cc.contestService.DeleteTestCase(ctx.Request().Context(), contestID, problemID, testCaseID, grants)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.TestCaseNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemSharedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete test case",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":    "test case deleted successfully",
+		"problemID":  problemID,
+		"testCaseID": testCaseID,
+	})
+}
+
+func (cc *ContestController) HandleListAssets(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+
+	assets, err := cc.contestService.ListAssets(ctx.Request().Context(), contestID, problemID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list assets",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, assets)
+}
+
+// HandleCreateAsset uploads the multipart file "file". The optional form field "name"
+// sets the name the description refers to it by, defaulting to the file name.
+func (cc *ContestController) HandleCreateAsset(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	problemID := ctx.Param("problemid")
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": "file is required",
+		})
+	}
+
+	if fileHeader.Size > maxAssetSize {
+		return ctx.JSON(http.StatusRequestEntityTooLarge, map[string]string{
+			"error": "file is too large",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return ctx.NoContent(http.StatusInternalServerError)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return ctx.NoContent(http.StatusInternalServerError)
+	}
+
+	name := ctx.FormValue("name")
+	if name == "" {
+		name = fileHeader.Filename
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(data)
+	}
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	asset, err := cc.contestService.CreateAsset(ctx.Request().Context(), contestID, problemID, name, contentType, string(data), grants)
 	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.InvalidAssetNameError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.AssetAlreadyExistsError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemSharedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to update problem",
+			"error": "failed to upload asset",
 		})
 	}
 
-	return ctx.JSON(http.StatusOK, updatedProblem)
+	return ctx.JSON(http.StatusCreated, asset)
 }
 
-func (cc *ContestController) HandleDeleteProblem(ctx echo.Context) error {
-
+func (cc *ContestController) HandleDeleteAsset(ctx echo.Context) error {
 	contestID := ctx.Param("contestid")
 	problemID := ctx.Param("problemid")
-	if contestID == "" || problemID == "" {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{
-			"error": "contest ID and problem ID are required",
-		})
-	}
+	assetID := ctx.Param("assetid")
 
-	err := cc.contestService.DeleteProblem(ctx.Request().Context(), contestID, problemID)
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	err := cc.contestService.DeleteAsset(ctx.Request().Context(), contestID, problemID, assetID, grants)
 	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": "problem not found",
+			})
+		} else if err == common.AssetNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemSharedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ProblemOutOfScopeError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "failed to delete problem",
+			"error": "failed to delete asset",
 		})
 	}
 
 	return ctx.JSON(http.StatusOK, map[string]string{
-		"message":   "problem deleted successfully",
-		"contestID": contestID,
+		"message":   "asset deleted successfully",
 		"problemID": problemID,
+		"assetID":   assetID,
 	})
 }
 
@@ -279,8 +1632,26 @@ func (cc *ContestController) HandleUpdateLeaderboardUser(ctx echo.Context) error
 		})
 	}
 
-	err := cc.contestService.UpdateLeaderboardUser(ctx.Request().Context(), contestID, userID, &req)
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	if before, err := cc.contestService.GetRanking(ctx.Request().Context(), contestID, userID); err == nil && before != nil {
+		ctx.Set(common.AUDIT_BEFORE, before)
+	}
+
+	err := cc.contestService.UpdateLeaderboardUser(ctx.Request().Context(), contestID, userID, adminID, &req)
 	if err != nil {
+		if err == common.DisqualificationReasonError ||
+			err == common.InvalidEvidenceError ||
+			err == common.UserNotDisqualifiedError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.AppealAlreadyPendingError ||
+			err == common.UserAlreadyDisqualifiedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "failed to update leaderboard",
 		})
@@ -292,6 +1663,83 @@ func (cc *ContestController) HandleUpdateLeaderboardUser(ctx echo.Context) error
 		"userID":    userID,
 	})
 }
+
+func (cc *ContestController) HandleListDisqualifications(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	userID := ctx.Param("userid")
+
+	history, err := cc.contestService.ListDisqualifications(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list disqualifications",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, history)
+}
+
+func (cc *ContestController) HandleListAppeals(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.ListAppealsRequest)
+
+	appeals, err := cc.contestService.ListAppeals(ctx.Request().Context(), contestID, req.Status, req.Page)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list appeals",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, appeals)
+}
+
+func (cc *ContestController) HandleResolveAppeal(ctx echo.Context) error {
+	contestID := ctx.Param("contestid")
+	appealID := ctx.Param("appealid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.ResolveAppealRequest)
+
+	appeal, err := cc.contestService.ResolveAppeal(ctx.Request().Context(), contestID, appealID, adminID, req)
+	if err != nil {
+		if err == common.AppealNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.AppealAlreadyResolvedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to resolve appeal",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, appeal)
+}
+
+func (cc *ContestController) CreateAppeal(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+	reqBody := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.CreateAppealRequest)
+
+	appeal, err := cc.contestService.CreateAppeal(ctx.Request().Context(), contestID, userID, reqBody.Message)
+	if err != nil {
+		if err == common.UserNotDisqualifiedError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.AppealAlreadyPendingError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create appeal",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, appeal)
+}
 func (cc *ContestController) GetContest(ctx echo.Context) error {
 	contestID := ctx.Param("id")
 
@@ -319,6 +1767,82 @@ func (cc *ContestController) GetContest(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, contest)
 }
 
+func (cc *ContestController) StartAttempt(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	attempt, err := cc.contestService.StartAttempt(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.UserNotRegisteredError ||
+			err == common.ContestNotRunningError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.ContestNotTimedError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to start attempt",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, attempt)
+}
+
+func (cc *ContestController) StartVirtual(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	virtual, err := cc.contestService.StartVirtual(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.VirtualNotAvailableError ||
+			err == common.AlreadyParticipatedError ||
+			errors.Is(err, common.NotEligibleError) {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to start virtual participation",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, virtual)
+}
+
+func (cc *ContestController) GetVirtualLeaderboard(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	leaderboard, err := cc.contestService.GetVirtualLeaderboard(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.VirtualNotStartedError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get virtual leaderboard",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, leaderboard)
+}
+
 func (cc *ContestController) GetContestProblemsList(ctx echo.Context) error {
 	contestID := ctx.Param("id")
 	userID := ctx.Get(common.AUTH_USER_ID).(string)
@@ -330,7 +1854,9 @@ func (cc *ContestController) GetContestProblemsList(ctx echo.Context) error {
 				"error": common.ContestNotFoundError.Error(),
 			})
 		} else if err == common.UserNotRegisteredError ||
-			err == common.ContestNotRunningError {
+			err == common.ContestNotRunningError ||
+			err == common.AttemptNotStartedError ||
+			err == common.AttemptExpiredError {
 			return ctx.JSON(http.StatusForbidden, map[string]string{
 				"error": err.Error(),
 			})
@@ -340,7 +1866,7 @@ func (cc *ContestController) GetContestProblemsList(ctx echo.Context) error {
 		})
 	}
 
-	problems, err := cc.contestService.GetContestProblemsList(ctx.Request().Context(), contestID)
+	problems, err := cc.contestService.GetContestProblemsList(ctx.Request().Context(), contestID, userID)
 	if err != nil {
 		if err == common.ContestNotFoundError {
 			return ctx.JSON(http.StatusNotFound, map[string]string{
@@ -367,7 +1893,9 @@ func (cc *ContestController) GetContestProblem(ctx echo.Context) error {
 				"error": common.ContestNotFoundError.Error(),
 			})
 		} else if err == common.UserNotRegisteredError ||
-			err == common.ContestNotRunningError {
+			err == common.ContestNotRunningError ||
+			err == common.AttemptNotStartedError ||
+			err == common.AttemptExpiredError {
 			return ctx.JSON(http.StatusForbidden, map[string]string{
 				"error": err.Error(),
 			})
@@ -377,11 +1905,12 @@ func (cc *ContestController) GetContestProblem(ctx echo.Context) error {
 		})
 	}
 
-	problem, err := cc.contestService.GetContestProblem(ctx.Request().Context(), contestID, problemID)
+	problem, err := cc.contestService.GetContestProblem(ctx.Request().Context(), contestID, problemID, userID)
 	if err != nil {
-		if err == common.ContestNotFoundError {
+		if err == common.ContestNotFoundError ||
+			err == common.ProblemNotFoundError {
 			return ctx.JSON(http.StatusNotFound, map[string]string{
-				"error": common.ContestNotFoundError.Error(),
+				"error": err.Error(),
 			})
 		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
@@ -391,3 +1920,106 @@ func (cc *ContestController) GetContestProblem(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, problem)
 }
+
+func (cc *ContestController) GetProblemAsset(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	problemID := ctx.Param("problem_id")
+	name := ctx.Param("name")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	err := cc.contestService.GetProblemVisibility(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": common.ContestNotFoundError.Error(),
+			})
+		} else if err == common.UserNotRegisteredError ||
+			err == common.ContestNotRunningError ||
+			err == common.AttemptNotStartedError ||
+			err == common.AttemptExpiredError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": common.FetchContestFailedError.Error(),
+		})
+	}
+
+	asset, data, err := cc.contestService.GetAsset(ctx.Request().Context(), contestID, problemID, name, userID)
+	if err != nil {
+		if err == common.ContestNotFoundError || err == common.AssetNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": common.AssetNotFoundError.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get asset",
+		})
+	}
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "private, max-age=300")
+	return ctx.Blob(http.StatusOK, asset.ContentType, []byte(data))
+}
+
+func (cc *ContestController) GetContestSections(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	err := cc.contestService.GetProblemVisibility(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		if err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": common.ContestNotFoundError.Error(),
+			})
+		} else if err == common.UserNotRegisteredError ||
+			err == common.ContestNotRunningError ||
+			err == common.AttemptNotStartedError ||
+			err == common.AttemptExpiredError {
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": common.FetchContestFailedError.Error(),
+		})
+	}
+
+	sections, err := cc.contestService.ListSections(ctx.Request().Context(), contestID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get contest sections",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, sections)
+}
+
+func (cc *ContestController) GetProblemEditorial(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	problemID := ctx.Param("problem_id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	editorial, err := cc.contestService.GetProblemEditorial(ctx.Request().Context(), contestID, problemID, userID)
+	if err != nil {
+		switch err {
+		case common.ContestNotFoundError, common.ProblemNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.EditorialNotAvailableError:
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		case common.EditorialNotFoundError, common.ErrNotFound:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": common.EditorialNotFoundError.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get editorial",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, editorial)
+}
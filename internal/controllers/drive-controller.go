@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"app/internal/common"
+	"app/internal/models/dto"
+	"app/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DriveController struct {
+	driveService *services.DriveService
+}
+
+func NewDriveController(driveService *services.DriveService) *DriveController {
+	return &DriveController{
+		driveService: driveService,
+	}
+}
+
+func (dc *DriveController) GetDriveProgress(ctx echo.Context) error {
+	driveID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	progress, err := dc.driveService.GetProgress(ctx.Request().Context(), driveID, userID)
+	if err != nil {
+		if err == common.DriveNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get drive progress",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, progress)
+}
+
+func (dc *DriveController) HandleListDrives(ctx echo.Context) error {
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil {
+		page = 0
+	}
+
+	drives, err := dc.driveService.ListDrives(ctx.Request().Context(), page)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list drives",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, drives)
+}
+
+func (dc *DriveController) HandleGetDrive(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+
+	drive, err := dc.driveService.GetDrive(ctx.Request().Context(), driveID)
+	if err != nil {
+		if err == common.DriveNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get drive",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, drive)
+}
+
+func (dc *DriveController) HandleCreateDrive(ctx echo.Context) error {
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertDriveRequest)
+
+	drive, err := dc.driveService.CreateDrive(ctx.Request().Context(), adminID, req)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to create drive",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, drive)
+}
+
+func (dc *DriveController) HandleUpdateDrive(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertDriveRequest)
+
+	drive, err := dc.driveService.UpdateDrive(ctx.Request().Context(), driveID, req)
+	if err != nil {
+		if err == common.DriveNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update drive",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, drive)
+}
+
+func (dc *DriveController) HandleDeleteDrive(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+
+	if err := dc.driveService.DeleteDrive(ctx.Request().Context(), driveID); err != nil {
+		if err == common.DriveNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete drive",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "drive deleted successfully",
+		"driveID": driveID,
+	})
+}
+
+func (dc *DriveController) HandleSetDriveRounds(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.SetDriveRoundsRequest)
+
+	drive, err := dc.driveService.SetRounds(ctx.Request().Context(), driveID, req.ContestIDs)
+	if err != nil {
+		switch err {
+		case common.DriveNotFoundError, common.ContestNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.ContestInOtherDriveError:
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		case common.DuplicateRoundError:
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to update drive rounds",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, drive)
+}
+
+func (dc *DriveController) HandleGetDriveFunnel(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+
+	funnel, err := dc.driveService.GetFunnel(ctx.Request().Context(), driveID)
+	if err != nil {
+		if err == common.DriveNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to get drive funnel",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, funnel)
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"app/internal/common"
+	"app/internal/models/dto"
+	"app/internal/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type InterviewController struct {
+	interviewService *services.InterviewService
+}
+
+func NewInterviewController(interviewService *services.InterviewService) *InterviewController {
+	return &InterviewController{
+		interviewService: interviewService,
+	}
+}
+
+func (ic *InterviewController) ListInterviews(ctx echo.Context) error {
+	driveID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	interviews, err := ic.interviewService.ListCandidateInterviews(ctx.Request().Context(), driveID, userID)
+	if err != nil {
+		if err == common.DriveNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list interviews",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, interviews)
+}
+
+func (ic *InterviewController) HandleListInterviews(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+
+	interviews, err := ic.interviewService.ListInterviews(ctx.Request().Context(), driveID)
+	if err != nil {
+		if err == common.DriveNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list interviews",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, interviews)
+}
+
+func (ic *InterviewController) HandleCreateInterview(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertInterviewRequest)
+
+	interview, err := ic.interviewService.CreateInterview(ctx.Request().Context(), driveID, adminID, req)
+	if err != nil {
+		return ic.interviewError(ctx, err, "failed to create interview")
+	}
+
+	return ctx.JSON(http.StatusCreated, interview)
+}
+
+func (ic *InterviewController) HandleUpdateInterview(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+	interviewID := ctx.Param("interviewid")
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.UpsertInterviewRequest)
+
+	interview, err := ic.interviewService.UpdateInterview(ctx.Request().Context(), driveID, interviewID, req)
+	if err != nil {
+		return ic.interviewError(ctx, err, "failed to update interview")
+	}
+
+	return ctx.JSON(http.StatusOK, interview)
+}
+
+// interviewError maps the errors of scheduling an interview to responses
+func (ic *InterviewController) interviewError(ctx echo.Context, err error, message string) error {
+	switch err {
+	case common.DriveNotFoundError, common.InterviewNotFoundError:
+		return ctx.JSON(http.StatusNotFound, map[string]string{
+			"error": err.Error(),
+		})
+	case common.CandidateNotShortlistedError, common.InterviewerNotAdminError:
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
+
+func (ic *InterviewController) HandleDeleteInterview(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+	interviewID := ctx.Param("interviewid")
+
+	if err := ic.interviewService.DeleteInterview(ctx.Request().Context(), driveID, interviewID); err != nil {
+		if err == common.InterviewNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to delete interview",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message":     "interview deleted successfully",
+		"interviewID": interviewID,
+	})
+}
+
+func (ic *InterviewController) HandleListFeedback(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+	interviewID := ctx.Param("interviewid")
+
+	feedback, err := ic.interviewService.ListFeedback(ctx.Request().Context(), driveID, interviewID)
+	if err != nil {
+		if err == common.InterviewNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list feedback",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, feedback)
+}
+
+func (ic *InterviewController) HandleSubmitFeedback(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+	interviewID := ctx.Param("interviewid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.SubmitFeedbackRequest)
+
+	feedback, err := ic.interviewService.SubmitFeedback(ctx.Request().Context(), driveID, interviewID, adminID, req)
+	if err != nil {
+		switch err {
+		case common.InterviewNotFoundError:
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case common.InterviewerNotAssignedError:
+			return ctx.JSON(http.StatusForbidden, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to submit feedback",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, feedback)
+}
+
+func (ic *InterviewController) HandleListDecisions(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+
+	decisions, err := ic.interviewService.ListDecisions(ctx.Request().Context(), driveID)
+	if err != nil {
+		if err == common.DriveNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list decisions",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, decisions)
+}
+
+func (ic *InterviewController) HandleSetDecision(ctx echo.Context) error {
+	driveID := ctx.Param("driveid")
+	userID := ctx.Param("userid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.SetDecisionRequest)
+
+	decision, err := ic.interviewService.SetDecision(ctx.Request().Context(), driveID, userID, adminID, req)
+	if err != nil {
+		return ic.interviewError(ctx, err, "failed to set decision")
+	}
+
+	return ctx.JSON(http.StatusOK, decision)
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"app/internal/common"
+	"app/internal/models/dto"
+	"app/internal/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RoleController struct {
+	adminService *services.AdminService
+}
+
+func NewRoleController(adminService *services.AdminService) *RoleController {
+	return &RoleController{
+		adminService: adminService,
+	}
+}
+
+func (rc *RoleController) HandleGetMyRoles(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, ctx.Get(common.ADMIN_GRANTS))
+}
+
+func (rc *RoleController) HandleListRoles(ctx echo.Context) error {
+	grants, err := rc.adminService.ListGrants(ctx.Request().Context())
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list roles",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, grants)
+}
+
+func (rc *RoleController) HandleGrantRole(ctx echo.Context) error {
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.GrantRoleRequest)
+
+	grant, err := rc.adminService.GrantRole(ctx.Request().Context(), adminID, req)
+	if err != nil {
+		if err == common.UserNotFoundError ||
+			err == common.ContestNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.RoleAlreadyGrantedError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.SuperAdminScopedError {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to grant role",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, grant)
+}
+
+func (rc *RoleController) HandleRevokeRole(ctx echo.Context) error {
+	grantID := ctx.Param("grantid")
+
+	if err := rc.adminService.RevokeRole(ctx.Request().Context(), grantID); err != nil {
+		if err == common.RoleGrantNotFoundError {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if err == common.LastSuperAdminError {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to revoke role",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "role revoked successfully",
+		"grantID": grantID,
+	})
+}
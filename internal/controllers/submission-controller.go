@@ -101,17 +101,31 @@ func (sc *SubmissionController) SubmitSolution(ctx echo.Context) error {
 
 	contest_response, err := sc.contestService.GetContest(reqCtx, req.ContestID, userID)
 	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return ctx.NoContent(http.StatusNotFound)
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "failed to check contest registration",
 		})
 	}
-	if !*contest_response.IsRegistered {
-		return ctx.NoContent(http.StatusForbidden)
+	practice, virtual, err := sc.contestService.CheckSubmissionWindow(contest_response)
+	if err != nil {
+		if errors.Is(err, common.UserNotRegisteredError) {
+			return ctx.NoContent(http.StatusForbidden)
+		}
+		return ctx.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	}
+
+	if err := sc.contestService.CheckProblemInSet(reqCtx, req.ContestID, userID, req.ProblemID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return ctx.NoContent(http.StatusNotFound)
+		}
+		return ctx.NoContent(http.StatusInternalServerError)
 	}
 
 	submissionType := req.Type
 
-	submissionID, err := sc.submissionService.CreateSubmission(reqCtx, userID, submissionType, req)
+	submissionID, err := sc.submissionService.CreateSubmission(reqCtx, userID, submissionType, practice, virtual, req)
 	if err != nil {
 		if errors.Is(err, common.ErrNotFound) {
 			return ctx.NoContent(http.StatusNotFound)
@@ -119,6 +133,9 @@ func (sc *SubmissionController) SubmitSolution(ctx echo.Context) error {
 		if errors.Is(err, common.KeyAlreadyExistsError) {
 			return ctx.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
 		}
+		if errors.Is(err, common.InvalidOptionError) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return ctx.NoContent(http.StatusInternalServerError)
 	}
 
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"app/internal/common"
+	"app/internal/models/dto"
+	"app/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SuspensionController struct {
+	suspensionService *services.SuspensionService
+}
+
+func NewSuspensionController(suspensionService *services.SuspensionService) *SuspensionController {
+	return &SuspensionController{
+		suspensionService: suspensionService,
+	}
+}
+
+func (sc *SuspensionController) HandleListSuspensions(ctx echo.Context) error {
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.ListSuspensionsRequest)
+
+	suspensions, err := sc.suspensionService.ListSuspensions(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to list suspensions",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, suspensions)
+}
+
+func (sc *SuspensionController) HandleSuspendUser(ctx echo.Context) error {
+	userID := ctx.Param("userid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.CreateSuspensionRequest)
+
+	suspension, err := sc.suspensionService.Suspend(ctx.Request().Context(), userID, adminID, req)
+	if err != nil {
+		if errors.Is(err, common.UserNotFoundError) ||
+			errors.Is(err, common.ContestNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if errors.Is(err, common.InvalidSuspensionExpiryError) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to suspend user",
+		})
+	}
+
+	return ctx.JSON(http.StatusCreated, suspension)
+}
+
+func (sc *SuspensionController) HandleLiftSuspension(ctx echo.Context) error {
+	suspensionID := ctx.Param("suspensionid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.LiftSuspensionRequest)
+
+	suspension, err := sc.suspensionService.Lift(ctx.Request().Context(), suspensionID, adminID, req.Reason)
+	if err != nil {
+		if errors.Is(err, common.SuspensionNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		} else if errors.Is(err, common.SuspensionAlreadyLiftedError) {
+			return ctx.JSON(http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to lift suspension",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, suspension)
+}
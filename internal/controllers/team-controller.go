@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"app/internal/common"
+	"app/internal/models/dto"
+	"app/internal/services"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TeamController struct {
+	teamService *services.TeamService
+}
+
+func NewTeamController(teamService *services.TeamService) *TeamController {
+	return &TeamController{
+		teamService: teamService,
+	}
+}
+
+// teamError maps the errors of team operations to responses
+func (tc *TeamController) teamError(ctx echo.Context, err error, message string) error {
+	switch {
+	case err == common.ContestNotFoundError, err == common.TeamNotFoundError:
+		return ctx.JSON(http.StatusNotFound, map[string]string{
+			"error": err.Error(),
+		})
+	case err == common.ContestNotTeamModeError, errors.Is(err, common.TeamSizeError):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	case err == common.ContestRegistrationClosedError,
+		err == common.NotTeamLeaderError,
+		err == common.AttemptAlreadyStartedError,
+		errors.Is(err, common.NotEligibleError),
+		errors.Is(err, common.AccountSuspendedError):
+		return ctx.JSON(http.StatusForbidden, map[string]string{
+			"error": err.Error(),
+		})
+	case err == common.TeamNameTakenError,
+		err == common.AlreadyInTeamError,
+		err == common.TeamFullError,
+		err == common.TeamAlreadyRegisteredError,
+		err == common.TeamNotRegisteredError,
+		err == common.ContestFullError:
+		return ctx.JSON(http.StatusConflict, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
+
+func (tc *TeamController) GetLeaderboard(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+
+	pageStr := ctx.QueryParam("page")
+
+	page, err := strconv.Atoi(pageStr)
+	if err != nil {
+		page = 0
+	}
+
+	rankings, err := tc.teamService.GetLeaderboard(ctx.Request().Context(), contestID, page)
+	if err != nil {
+		return tc.teamError(ctx, err, "failed to get team leaderboard")
+	}
+
+	return ctx.JSON(http.StatusOK, rankings)
+}
+
+func (tc *TeamController) GetTeam(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	team, err := tc.teamService.GetTeam(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		return tc.teamError(ctx, err, "failed to get team")
+	}
+
+	return ctx.JSON(http.StatusOK, team)
+}
+
+func (tc *TeamController) CreateTeam(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.CreateTeamRequest)
+
+	team, err := tc.teamService.CreateTeam(ctx.Request().Context(), contestID, userID, req.Name)
+	if err != nil {
+		return tc.teamError(ctx, err, "failed to create team")
+	}
+
+	return ctx.JSON(http.StatusCreated, team)
+}
+
+func (tc *TeamController) JoinTeam(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.JoinTeamRequest)
+
+	team, err := tc.teamService.JoinTeam(ctx.Request().Context(), contestID, userID, req.InviteCode)
+	if err != nil {
+		return tc.teamError(ctx, err, "failed to join team")
+	}
+
+	return ctx.JSON(http.StatusOK, team)
+}
+
+func (tc *TeamController) LeaveTeam(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	if err := tc.teamService.LeaveTeam(ctx.Request().Context(), contestID, userID); err != nil {
+		return tc.teamError(ctx, err, "failed to leave team")
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+func (tc *TeamController) RegisterTeam(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	team, err := tc.teamService.RegisterTeam(ctx.Request().Context(), contestID, userID)
+	if err != nil {
+		return tc.teamError(ctx, err, "failed to register team")
+	}
+
+	return ctx.JSON(http.StatusOK, team)
+}
+
+func (tc *TeamController) UnregisterTeam(ctx echo.Context) error {
+	contestID := ctx.Param("id")
+	userID := ctx.Get(common.AUTH_USER_ID).(string)
+
+	if err := tc.teamService.UnregisterTeam(ctx.Request().Context(), contestID, userID); err != nil {
+		return tc.teamError(ctx, err, "failed to unregister team")
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}
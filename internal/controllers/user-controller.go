@@ -2,11 +2,13 @@ package controllers
 
 import (
 	"app/internal/common"
+	"app/internal/models"
 	"app/internal/models/dto"
 	"app/internal/services"
 	"errors"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
@@ -100,3 +102,111 @@ func (uc *UserController) UpdateUserProfile(ctx echo.Context) error {
 
 	return ctx.NoContent(http.StatusCreated)
 }
+
+func (uc *UserController) HandleListUsers(ctx echo.Context) error {
+	req := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.AdminListUsersRequest)
+
+	users, err := uc.userService.SearchUsers(ctx.Request().Context(), req)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to list users"})
+	}
+
+	return ctx.JSON(http.StatusOK, users)
+}
+
+func (uc *UserController) HandleGetUser(ctx echo.Context) error {
+	userID := ctx.Param("userid")
+
+	page, err := strconv.Atoi(ctx.QueryParam("page"))
+	if err != nil {
+		page = 0
+	}
+
+	user, err := uc.userService.GetUserDetails(ctx.Request().Context(), userID, page)
+	if err != nil {
+		if errors.Is(err, common.UserNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": common.FetchUserFailedError.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, user)
+}
+
+func (uc *UserController) HandleUpdateUser(ctx echo.Context) error {
+	userID := ctx.Param("userid")
+	reqBody := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.AdminUpdateUserRequest)
+
+	if err := validateUserInput(reqBody.USN, reqBody.MobileNumber, reqBody.CurrentYear); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	before, err := uc.userService.GetAdminUser(ctx.Request().Context(), userID)
+	if err != nil {
+		if errors.Is(err, common.UserNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": common.UpdateUserFailedError.Error()})
+	}
+	ctx.Set(common.AUDIT_BEFORE, before)
+
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	if err := uc.userService.AdminUpdateUser(ctx.Request().Context(), userID, reqBody, grants); err != nil {
+		if errors.Is(err, common.UserNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		} else if errors.Is(err, common.RoleNotHigherError) {
+			return ctx.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		} else if errors.Is(err, common.USNTakenError) {
+			return ctx.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": common.UpdateUserFailedError.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "user updated successfully",
+		"userID":  userID,
+	})
+}
+
+func (uc *UserController) HandleBanUser(ctx echo.Context) error {
+	userID := ctx.Param("userid")
+	adminID := ctx.Get(common.AUTH_USER_ID).(string)
+	reqBody := ctx.Get(common.VALIDATED_REQUEST_BODY).(*dto.BanUserRequest)
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	if err := uc.userService.BanUser(ctx.Request().Context(), userID, adminID, reqBody.Reason, grants); err != nil {
+		if errors.Is(err, common.UserNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		} else if errors.Is(err, common.CannotBanSelfError) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		} else if errors.Is(err, common.RoleNotHigherError) {
+			return ctx.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to ban user"})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "user banned successfully",
+		"userID":  userID,
+	})
+}
+
+func (uc *UserController) HandleUnbanUser(ctx echo.Context) error {
+	userID := ctx.Param("userid")
+	grants, _ := ctx.Get(common.ADMIN_GRANTS).(models.Grants)
+
+	if err := uc.userService.UnbanUser(ctx.Request().Context(), userID, grants); err != nil {
+		if errors.Is(err, common.UserNotFoundError) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		} else if errors.Is(err, common.RoleNotHigherError) {
+			return ctx.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to unban user"})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"message": "user unbanned successfully",
+		"userID":  userID,
+	})
+}
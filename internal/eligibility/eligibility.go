@@ -0,0 +1,98 @@
+// Package eligibility decides whether a user may register for a contest
+package eligibility
+
+import (
+	"app/internal/models"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Result is the outcome of an eligibility check, with the reason shown to the user on rejection
+type Result struct {
+	Eligible bool   `json:"eligible"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func eligible() Result {
+	return Result{Eligible: true}
+}
+
+func rejected(reason string) Result {
+	return Result{Reason: reason}
+}
+
+// Check evaluates the contest's rules for a user. The access list decides first: denied users are
+// always rejected and allowed users always accepted. Otherwise private and invite-only contests reject everyone,
+// and every other rule that is set must hold.
+func Check(contest *models.Contest, user *models.User, access models.ContestAccess) Result {
+	switch access {
+	case models.AccessDeny:
+		return rejected("you are not allowed to register for this contest")
+	case models.AccessAllow:
+		return eligible()
+	}
+
+	if contest.Private {
+		return rejected("this contest is private, register with an invite code")
+	}
+
+	if contest.InviteOnly {
+		return rejected("this contest is invite only")
+	}
+
+	if len(contest.EligibleTo) > 0 && !slices.Contains(contest.EligibleTo, user.CurrentYear) {
+		return rejected(fmt.Sprintf("this contest is only open to %s year students", formatYears(contest.EligibleTo)))
+	}
+
+	if len(contest.Departments) > 0 && !slices.ContainsFunc(contest.Departments, func(d string) bool {
+		return strings.EqualFold(d, user.Department)
+	}) {
+		return rejected(fmt.Sprintf("this contest is only open to the %s departments", strings.Join(contest.Departments, ", ")))
+	}
+
+	if contest.USNPattern != "" {
+		pattern, err := CompileUSNPattern(contest.USNPattern)
+		if err != nil || !pattern.MatchString(strings.ToUpper(user.USN)) {
+			return rejected("your USN is not eligible for this contest")
+		}
+	}
+
+	return eligible()
+}
+
+// CompileUSNPattern compiles a USN pattern so that it must match the whole USN, ignoring case
+func CompileUSNPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`(?i)^(?:` + pattern + `)$`)
+}
+
+// formatYears lists years as "1st, 2nd or 3rd"
+func formatYears(years []int) string {
+	sorted := slices.Clone(years)
+	slices.Sort(sorted)
+
+	names := make([]string, len(sorted))
+	for i, year := range sorted {
+		names[i] = ordinal(year)
+	}
+
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+func ordinal(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return fmt.Sprintf("%dth", n)
+	case n%10 == 1:
+		return fmt.Sprintf("%dst", n)
+	case n%10 == 2:
+		return fmt.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return fmt.Sprintf("%drd", n)
+	}
+	return fmt.Sprintf("%dth", n)
+}
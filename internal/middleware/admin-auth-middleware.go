@@ -2,12 +2,15 @@ package middleware
 
 import (
 	"app/internal/common"
+	"app/internal/models"
 	"app/internal/services"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 )
 
+// AdminAuth lets through users holding at least one role and stores their grants
+// in the context for RequirePermission
 func AdminAuth(userService *services.UserService, adminService *services.AdminService) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -25,24 +28,44 @@ func AdminAuth(userService *services.UserService, adminService *services.AdminSe
 				})
 			}
 
-			isAdmin, err := adminService.IsAdmin(c.Request().Context(), user.ID)
+			grants, err := adminService.GetGrants(c.Request().Context(), user.ID)
 			if err != nil {
 				return c.JSON(http.StatusInternalServerError, map[string]string{
 					"error": "could not verify admin status",
 				})
 			}
 
-			if !isAdmin {
+			if len(grants) == 0 {
 				return c.JSON(http.StatusForbidden, map[string]string{
 					"error": "admin access required",
 				})
 			}
 
+			c.Set(common.ADMIN_GRANTS, grants)
 			return next(c)
 		}
 	}
 }
 
-func RequireAdminRole(userService *services.UserService, adminService *services.AdminService) echo.MiddlewareFunc {
-	return AdminAuth(userService, adminService)
+// RequirePermission lets through admins holding the permission globally or for the contest
+// in the route's contestid parameter. Must run after AdminAuth.
+func RequirePermission(permission models.Permission) echo.MiddlewareFunc {
+	return RequireContestPermission(permission, "contestid")
+}
+
+// RequireContestPermission is RequirePermission for routes naming the contest with another parameter
+func RequireContestPermission(permission models.Permission, param string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			grants, _ := c.Get(common.ADMIN_GRANTS).(models.Grants)
+
+			if !grants.Can(permission, c.Param(param)) {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"error": "missing permission " + string(permission),
+				})
+			}
+
+			return next(c)
+		}
+	}
 }
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"app/internal/services"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxAuditBody is the largest request body kept in the audit log
+const maxAuditBody = 64 << 10
+
+// Audit records every successful mutating request in the audit log. Handlers may store the state
+// they are about to change under common.AUDIT_BEFORE so that the entry shows what changed.
+func Audit(auditService *services.AuditService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+				return next(c)
+			}
+
+			// Keep a copy of JSON bodies for the log, the handler still reads the original.
+			// Uploads are left alone.
+			var body []byte
+			if req.Body != nil && strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+				b, err := io.ReadAll(req.Body)
+				if err != nil {
+					return c.JSON(http.StatusBadRequest, map[string]string{
+						"error": "Invalid request format",
+					})
+				}
+				req.Body = io.NopCloser(bytes.NewReader(b))
+				body = b
+			}
+
+			if err := next(c); err != nil {
+				return err
+			}
+
+			if c.Response().Status >= http.StatusBadRequest {
+				return nil
+			}
+
+			entry := &models.AuditEntry{
+				Action:    req.Method + " " + c.Path(),
+				Target:    make(map[string]string),
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+				IP:        c.RealIP(),
+			}
+
+			entry.ActorID, _ = c.Get(common.AUTH_USER_ID).(string)
+
+			for _, name := range c.ParamNames() {
+				entry.Target[name] = c.Param(name)
+			}
+
+			entry.ContestID = c.Param("contestid")
+			if entry.ContestID == "" && strings.HasPrefix(c.Path(), "/admin/contest/:id") {
+				entry.ContestID = c.Param("id")
+			}
+
+			if before := c.Get(common.AUDIT_BEFORE); before != nil {
+				if b, err := json.Marshal(before); err == nil {
+					entry.Before = b
+				}
+			}
+
+			if len(body) <= maxAuditBody && json.Valid(body) {
+				entry.After = body
+			}
+
+			if err := auditService.Record(req.Context(), entry); err != nil {
+				log.Printf("audit: failed to record %s by %s: %v", entry.Action, entry.ActorID, err)
+			}
+
+			return nil
+		}
+	}
+}
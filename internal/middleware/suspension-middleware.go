@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"app/internal/common"
+	"app/internal/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// contestScoped is implemented by request bodies naming the contest they act on
+type contestScoped interface {
+	GetContestID() string
+}
+
+// RequireNotSuspended rejects users suspended globally or from the contest in the route's id parameter,
+// or in the validated request body for routes without one. Must run after RequireFirebaseAuth.
+func RequireNotSuspended(suspensionService *services.SuspensionService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := c.Get(common.AUTH_USER_ID).(string)
+			if !ok || userID == "" {
+				return c.JSON(http.StatusInternalServerError, map[string]string{
+					"error": "user identity not found in context",
+				})
+			}
+
+			contestID := c.Param("id")
+			if body, ok := c.Get(common.VALIDATED_REQUEST_BODY).(contestScoped); ok && contestID == "" {
+				contestID = body.GetContestID()
+			}
+
+			suspension, err := suspensionService.GetActiveSuspension(c.Request().Context(), userID, contestID)
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, map[string]string{
+					"error": "could not verify account status",
+				})
+			}
+
+			if suspension != nil {
+				return c.JSON(http.StatusForbidden, map[string]any{
+					"error":      common.AccountSuspendedError.Error(),
+					"reason":     suspension.Reason,
+					"contest_id": suspension.ContestID, // Empty for a global suspension
+					"expires_at": suspension.ExpiresAt, // Unix timestamp in milliseconds, zero for indefinitely
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS disqualification_appeals;
+DROP TYPE IF EXISTS appeal_status;
+DROP TABLE IF EXISTS disqualifications;
+DROP TYPE IF EXISTS disqualification_action;
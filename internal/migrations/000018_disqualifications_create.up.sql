@@ -0,0 +1,35 @@
+CREATE TYPE disqualification_action AS ENUM ('disqualify', 'reinstate');
+
+CREATE TABLE disqualifications (
+    id TEXT PRIMARY KEY, -- UUID
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action disqualification_action NOT NULL,
+    reason TEXT NOT NULL,
+    evidence TEXT[] NOT NULL DEFAULT '{}', -- Submission IDs
+    admin_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at BIGINT NOT NULL,
+    seq BIGSERIAL NOT NULL -- Orders the history, created_at only has second resolution
+);
+
+CREATE INDEX disqualifications_contest_user_idx ON disqualifications (contest_id, user_id, seq);
+
+CREATE TYPE appeal_status AS ENUM ('pending', 'accepted', 'rejected');
+
+CREATE TABLE disqualification_appeals (
+    id TEXT PRIMARY KEY, -- UUID
+    disqualification_id TEXT NOT NULL REFERENCES disqualifications(id) ON DELETE CASCADE,
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    status appeal_status NOT NULL DEFAULT 'pending',
+    response TEXT,
+    resolved_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at BIGINT NOT NULL,
+    resolved_at BIGINT
+);
+
+-- At most one pending appeal per user per contest
+CREATE UNIQUE INDEX disqualification_appeals_pending_idx
+    ON disqualification_appeals (contest_id, user_id)
+    WHERE status = 'pending';
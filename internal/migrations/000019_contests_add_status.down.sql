@@ -0,0 +1,2 @@
+ALTER TABLE contests DROP COLUMN status;
+DROP TYPE IF EXISTS contest_status;
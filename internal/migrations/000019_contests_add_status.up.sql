@@ -0,0 +1,5 @@
+CREATE TYPE contest_status AS ENUM ('draft', 'published', 'archived');
+
+-- Existing contests are already public, new ones start as drafts
+ALTER TABLE contests ADD COLUMN status contest_status NOT NULL DEFAULT 'published';
+ALTER TABLE contests ALTER COLUMN status SET DEFAULT 'draft';
@@ -0,0 +1,9 @@
+-- Test case input/output files are stored in S3
+CREATE TABLE test_cases (
+    id TEXT PRIMARY KEY, -- UUID
+    problem_id TEXT NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    is_sample BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX test_cases_problem_idx ON test_cases (problem_id);
@@ -0,0 +1 @@
+ALTER TABLE contests DROP COLUMN is_template;
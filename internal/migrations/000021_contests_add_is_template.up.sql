@@ -0,0 +1 @@
+ALTER TABLE contests ADD COLUMN is_template BOOLEAN NOT NULL DEFAULT FALSE;
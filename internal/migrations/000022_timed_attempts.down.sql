@@ -0,0 +1,2 @@
+ALTER TABLE contest_registrations DROP COLUMN started_at;
+ALTER TABLE contests DROP COLUMN duration;
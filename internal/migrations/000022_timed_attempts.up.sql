@@ -0,0 +1,5 @@
+-- Duration of a personal attempt in seconds, 0 means the attempt spans the whole contest
+ALTER TABLE contests ADD COLUMN duration BIGINT NOT NULL DEFAULT 0;
+
+-- Unix timestamp in milliseconds, like the contest times
+ALTER TABLE contest_registrations ADD COLUMN started_at BIGINT;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS problem_options;
+ALTER TABLE problems DROP COLUMN next_option_id;
+ALTER TABLE problems DROP COLUMN multiple_choice;
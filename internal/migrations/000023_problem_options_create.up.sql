@@ -0,0 +1,15 @@
+ALTER TABLE problems ADD COLUMN multiple_choice BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Next ID to give an option of the problem, so that IDs of deleted options are never reused
+ALTER TABLE problems ADD COLUMN next_option_id INT NOT NULL DEFAULT 1;
+
+-- Option IDs are unique within a problem and never reused, problems.answer and
+-- submissions.choices refer to them
+CREATE TABLE problem_options (
+    problem_id TEXT NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    id INT NOT NULL,
+    position INT NOT NULL,
+    text TEXT NOT NULL, -- Markdown
+    image TEXT,
+    PRIMARY KEY (problem_id, id)
+);
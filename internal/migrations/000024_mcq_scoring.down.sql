@@ -0,0 +1,6 @@
+ALTER TABLE contests DROP COLUMN clamp_score;
+ALTER TABLE submissions DROP COLUMN seq;
+ALTER TABLE submissions DROP COLUMN score;
+ALTER TABLE problem_options DROP COLUMN weight;
+ALTER TABLE problems DROP COLUMN partial_credit;
+ALTER TABLE problems DROP COLUMN negative_marks;
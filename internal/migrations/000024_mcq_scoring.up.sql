@@ -0,0 +1,15 @@
+-- Marks deducted for a wrong MCQ answer, unanswered problems score zero
+ALTER TABLE problems ADD COLUMN negative_marks INT NOT NULL DEFAULT 0;
+-- Multi-select answers that pick only some of the correct options earn their share of the score
+ALTER TABLE problems ADD COLUMN partial_credit BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Share of the partial credit earned by picking this option when it is correct
+ALTER TABLE problem_options ADD COLUMN weight INT NOT NULL DEFAULT 1;
+
+-- Score awarded by the latest grading, may be negative. NULL until graded.
+ALTER TABLE submissions ADD COLUMN score INT;
+-- Orders the submissions of a user to a problem for scoring, created_at only has second resolution
+ALTER TABLE submissions ADD COLUMN seq BIGSERIAL NOT NULL;
+
+-- Keeps the total contest score from going below zero
+ALTER TABLE contests ADD COLUMN clamp_score BOOLEAN NOT NULL DEFAULT FALSE;
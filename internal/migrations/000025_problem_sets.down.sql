@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS problem_sets;
+DROP TABLE IF EXISTS contest_pools;
+ALTER TABLE problems DROP COLUMN pool;
+ALTER TABLE contests DROP COLUMN shuffle_options;
+ALTER TABLE contests DROP COLUMN shuffle_problems;
@@ -0,0 +1,24 @@
+ALTER TABLE contests ADD COLUMN shuffle_problems BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE contests ADD COLUMN shuffle_options BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Tag of the pool a problem is drawn from, NULL if every participant gets the problem
+ALTER TABLE problems ADD COLUMN pool TEXT;
+
+-- Each participant is given draw problems out of those tagged with the pool
+CREATE TABLE contest_pools (
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    draw INT NOT NULL CHECK (draw > 0),
+    PRIMARY KEY (contest_id, tag)
+);
+
+-- The problems drawn for a participant and the order they are presented in,
+-- fixed the first time the participant views the problems
+CREATE TABLE problem_sets (
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    problem_id TEXT NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    option_order INT[], -- Option IDs in presentation order, NULL if not shuffled
+    PRIMARY KEY (contest_id, user_id, problem_id)
+);
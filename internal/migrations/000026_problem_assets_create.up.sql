@@ -0,0 +1,11 @@
+-- Images and attachments of a problem statement, the files are stored in S3
+-- under problems/<problem_id>/assets/<id>
+CREATE TABLE problem_assets (
+    id TEXT PRIMARY KEY, -- UUID
+    problem_id TEXT NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    name TEXT NOT NULL, -- Referenced from the description as asset://<name>
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    created_at BIGINT NOT NULL,
+    UNIQUE (problem_id, name)
+);
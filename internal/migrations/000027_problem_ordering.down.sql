@@ -0,0 +1,4 @@
+ALTER TABLE problems DROP COLUMN section_id;
+ALTER TABLE problems DROP COLUMN label;
+ALTER TABLE problems DROP COLUMN position;
+DROP TABLE IF EXISTS contest_sections;
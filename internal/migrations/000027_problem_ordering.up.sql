@@ -0,0 +1,22 @@
+-- Groups of problems with their own instructions, such as "Aptitude" or "Coding"
+CREATE TABLE contest_sections (
+    id TEXT PRIMARY KEY, -- UUID
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    instructions TEXT NOT NULL DEFAULT '', -- Markdown
+    position INT NOT NULL
+);
+
+ALTER TABLE problems ADD COLUMN position INT NOT NULL DEFAULT 0;
+ALTER TABLE problems ADD COLUMN label TEXT NOT NULL DEFAULT '';
+ALTER TABLE problems ADD COLUMN section_id TEXT REFERENCES contest_sections(id) ON DELETE SET NULL;
+
+-- Existing problems keep the alphabetical order they were usually shown in, labelled A, B, C...
+UPDATE problems p
+SET position = o.rn,
+    label = CASE WHEN o.rn <= 26 THEN chr(64 + o.rn::INT) ELSE 'P' || o.rn END
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY contest_id ORDER BY name) AS rn
+    FROM problems
+) o
+WHERE p.id = o.id;
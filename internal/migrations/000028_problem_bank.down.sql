@@ -0,0 +1,30 @@
+ALTER TABLE problems
+    ADD COLUMN contest_id TEXT REFERENCES contests(id) ON DELETE CASCADE,
+    ADD COLUMN position INT NOT NULL DEFAULT 0,
+    ADD COLUMN label TEXT NOT NULL DEFAULT '',
+    ADD COLUMN section_id TEXT REFERENCES contest_sections(id) ON DELETE SET NULL,
+    ADD COLUMN pool TEXT;
+
+-- A problem attached to several contests goes back to one of them
+UPDATE problems p
+SET contest_id = cp.contest_id,
+    score = COALESCE(cp.score, p.score),
+    position = cp.position,
+    label = cp.label,
+    section_id = cp.section_id,
+    pool = cp.pool
+FROM (
+    SELECT DISTINCT ON (problem_id) *
+    FROM contest_problems
+    ORDER BY problem_id, contest_id
+) cp
+WHERE p.id = cp.problem_id;
+
+-- Problems only in the bank cannot be represented
+DELETE FROM problems WHERE contest_id IS NULL;
+ALTER TABLE problems ALTER COLUMN contest_id SET NOT NULL;
+
+DROP TABLE IF EXISTS contest_problems;
+ALTER TABLE problems DROP COLUMN difficulty;
+ALTER TABLE problems DROP COLUMN tags;
+DROP TYPE IF EXISTS problem_difficulty;
@@ -0,0 +1,29 @@
+CREATE TYPE problem_difficulty AS ENUM ('easy', 'medium', 'hard');
+
+ALTER TABLE problems ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE problems ADD COLUMN difficulty problem_difficulty;
+
+-- Problems are owned by the bank and attached to any number of contests
+CREATE TABLE contest_problems (
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    problem_id TEXT NOT NULL REFERENCES problems(id) ON DELETE CASCADE,
+    score INT, -- Overrides the problem's score in this contest, NULL uses the problem's score
+    position INT NOT NULL DEFAULT 0,
+    label TEXT NOT NULL DEFAULT '',
+    section_id TEXT REFERENCES contest_sections(id) ON DELETE SET NULL,
+    pool TEXT,
+    PRIMARY KEY (contest_id, problem_id)
+);
+
+CREATE INDEX contest_problems_problem_id_idx ON contest_problems (problem_id);
+
+INSERT INTO contest_problems (contest_id, problem_id, position, label, section_id, pool)
+SELECT contest_id, id, position, label, section_id, pool
+FROM problems;
+
+ALTER TABLE problems
+    DROP COLUMN contest_id,
+    DROP COLUMN position,
+    DROP COLUMN label,
+    DROP COLUMN section_id,
+    DROP COLUMN pool;
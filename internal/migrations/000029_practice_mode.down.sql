@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS problem_solutions;
+DROP TABLE IF EXISTS problem_editorials;
+ALTER TABLE submissions DROP COLUMN practice;
+ALTER TABLE contests DROP COLUMN practice_mode;
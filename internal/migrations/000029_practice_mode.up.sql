@@ -0,0 +1,28 @@
+-- Once the contest ends anyone logged in can view its problems and submit, without being ranked
+ALTER TABLE contests ADD COLUMN practice_mode BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Practice submissions are graded but never counted in rankings
+ALTER TABLE submissions ADD COLUMN practice BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Editorials belong to a problem as used in one contest, so reusing the problem elsewhere does not reveal them
+CREATE TABLE problem_editorials (
+    contest_id TEXT NOT NULL,
+    problem_id TEXT NOT NULL,
+    content TEXT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (contest_id, problem_id),
+    FOREIGN KEY (contest_id, problem_id) REFERENCES contest_problems(contest_id, problem_id) ON DELETE CASCADE
+);
+
+CREATE TABLE problem_solutions (
+    id TEXT PRIMARY KEY,
+    contest_id TEXT NOT NULL,
+    problem_id TEXT NOT NULL,
+    language TEXT NOT NULL,
+    code TEXT NOT NULL,
+    explanation TEXT NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL,
+    FOREIGN KEY (contest_id, problem_id) REFERENCES contest_problems(contest_id, problem_id) ON DELETE CASCADE
+);
+
+CREATE INDEX problem_solutions_problem_idx ON problem_solutions (contest_id, problem_id);
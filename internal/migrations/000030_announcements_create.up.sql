@@ -0,0 +1,12 @@
+CREATE TABLE announcements (
+    id TEXT PRIMARY KEY,
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    problem_id TEXT REFERENCES problems(id) ON DELETE SET NULL, -- NULL for announcements about the whole contest
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX announcements_contest_idx ON announcements (contest_id, created_at);
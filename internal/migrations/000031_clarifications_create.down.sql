@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS clarifications;
+DROP TYPE IF EXISTS clarification_status;
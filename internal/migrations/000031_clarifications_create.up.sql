@@ -0,0 +1,18 @@
+CREATE TYPE clarification_status AS ENUM ('pending', 'answered');
+
+CREATE TABLE clarifications (
+    id TEXT PRIMARY KEY, -- UUID
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    problem_id TEXT REFERENCES problems(id) ON DELETE SET NULL, -- NULL for questions about the whole contest
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    status clarification_status NOT NULL DEFAULT 'pending',
+    answer TEXT,
+    public BOOLEAN NOT NULL DEFAULT FALSE, -- Answer was broadcast to everyone in the contest
+    answered_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at BIGINT NOT NULL,
+    answered_at BIGINT
+);
+
+CREATE INDEX clarifications_contest_idx ON clarifications (contest_id, status, created_at);
+CREATE INDEX clarifications_user_idx ON clarifications (contest_id, user_id, created_at);
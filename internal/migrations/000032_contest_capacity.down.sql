@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS contest_waitlist;
+ALTER TABLE contests DROP COLUMN max_participants;
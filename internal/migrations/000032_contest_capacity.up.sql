@@ -0,0 +1,12 @@
+-- Seats in the contest, 0 for no limit. Registrations beyond it join the waitlist.
+ALTER TABLE contests ADD COLUMN max_participants INT NOT NULL DEFAULT 0;
+
+CREATE TABLE contest_waitlist (
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at BIGINT NOT NULL,
+    seq BIGSERIAL NOT NULL, -- Breaks ties between users joining in the same second
+    PRIMARY KEY (contest_id, user_id)
+);
+
+CREATE INDEX contest_waitlist_order_idx ON contest_waitlist (contest_id, seq);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS contest_access_list;
+DROP TYPE IF EXISTS contest_access;
+ALTER TABLE contests DROP COLUMN invite_only;
+ALTER TABLE contests DROP COLUMN usn_pattern;
+ALTER TABLE contests DROP COLUMN departments;
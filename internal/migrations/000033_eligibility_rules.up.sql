@@ -0,0 +1,16 @@
+-- Eligibility rules combined with eligible_to years, empty values do not restrict
+ALTER TABLE contests ADD COLUMN departments TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE contests ADD COLUMN usn_pattern TEXT NOT NULL DEFAULT ''; -- Regular expression the whole USN must match
+ALTER TABLE contests ADD COLUMN invite_only BOOLEAN NOT NULL DEFAULT FALSE; -- Only users on the allow list may register
+
+CREATE TYPE contest_access AS ENUM ('allow', 'deny');
+
+-- Users explicitly allowed regardless of the rules, or denied
+CREATE TABLE contest_access_list (
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    access contest_access NOT NULL,
+    added_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    added_at BIGINT NOT NULL,
+    PRIMARY KEY (contest_id, user_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS contest_invite_redemptions;
+DROP TABLE IF EXISTS contest_invite_codes;
+ALTER TABLE contests DROP COLUMN private;
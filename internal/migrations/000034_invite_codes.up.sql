@@ -0,0 +1,22 @@
+-- Private contests are not listed and need an invite to register
+ALTER TABLE contests ADD COLUMN private BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE contest_invite_codes (
+    code TEXT PRIMARY KEY,
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    max_uses INT NOT NULL DEFAULT 1, -- 0 for no limit
+    uses INT NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL DEFAULT 0, -- Unix ms, 0 if the code never expires
+    created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_contest_invite_codes_contest ON contest_invite_codes(contest_id);
+
+-- A user uses up a code at most once
+CREATE TABLE contest_invite_redemptions (
+    code TEXT NOT NULL REFERENCES contest_invite_codes(code) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    redeemed_at BIGINT NOT NULL,
+    PRIMARY KEY (code, user_id)
+);
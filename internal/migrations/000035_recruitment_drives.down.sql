@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS drive_rounds;
+DROP TABLE IF EXISTS recruitment_drives;
@@ -0,0 +1,16 @@
+CREATE TABLE recruitment_drives (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at BIGINT NOT NULL
+);
+
+-- Contests of a drive in the order they are run, users shortlisted in a round may take the next one
+CREATE TABLE drive_rounds (
+    drive_id TEXT NOT NULL REFERENCES recruitment_drives(id) ON DELETE CASCADE,
+    contest_id TEXT NOT NULL UNIQUE REFERENCES contests(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (drive_id, contest_id),
+    UNIQUE (drive_id, position)
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS drive_decisions;
+DROP TYPE IF EXISTS candidate_decision;
+DROP TABLE IF EXISTS interview_feedback;
+DROP TABLE IF EXISTS interview_interviewers;
+DROP TABLE IF EXISTS interviews;
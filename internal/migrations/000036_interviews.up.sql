@@ -0,0 +1,47 @@
+-- Interview slots of candidates in a recruitment drive
+CREATE TABLE interviews (
+    id TEXT PRIMARY KEY,
+    drive_id TEXT NOT NULL REFERENCES recruitment_drives(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    scheduled_at BIGINT NOT NULL, -- Unix ms
+    duration INT NOT NULL, -- Minutes
+    location TEXT NOT NULL DEFAULT '', -- Room or meeting link
+    created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_interviews_drive_user ON interviews(drive_id, user_id);
+
+CREATE TABLE interview_interviewers (
+    interview_id TEXT NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    admin_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (interview_id, admin_id)
+);
+
+-- Each interviewer fills one feedback form per interview, ratings are 1 to 5
+CREATE TABLE interview_feedback (
+    interview_id TEXT NOT NULL REFERENCES interviews(id) ON DELETE CASCADE,
+    interviewer_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    problem_solving INT NOT NULL CHECK (problem_solving BETWEEN 1 AND 5),
+    coding INT NOT NULL CHECK (coding BETWEEN 1 AND 5),
+    communication INT NOT NULL CHECK (communication BETWEEN 1 AND 5),
+    comments TEXT NOT NULL DEFAULT '',
+    submitted_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (interview_id, interviewer_id)
+);
+
+CREATE TYPE candidate_decision AS ENUM ('selected', 'rejected', 'on_hold');
+
+-- Final outcome of a candidate in a drive
+CREATE TABLE drive_decisions (
+    drive_id TEXT NOT NULL REFERENCES recruitment_drives(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    decision candidate_decision NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    decided_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    decided_at BIGINT NOT NULL,
+    PRIMARY KEY (drive_id, user_id)
+);
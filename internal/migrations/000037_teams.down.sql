@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS team_rankings_credit_code ON submissions;
+DROP FUNCTION IF EXISTS team_rankings_credit_code;
+DROP MATERIALIZED VIEW IF EXISTS team_ranking_mv;
+DROP TABLE IF EXISTS team_rankings;
+ALTER TABLE submissions DROP COLUMN team_id;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
+ALTER TABLE contests DROP COLUMN max_team_size;
+ALTER TABLE contests DROP COLUMN min_team_size;
+ALTER TABLE contests DROP COLUMN team_mode;
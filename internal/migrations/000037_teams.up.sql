@@ -0,0 +1,87 @@
+-- Team contests are registered for and ranked by team
+ALTER TABLE contests ADD COLUMN team_mode BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE contests ADD COLUMN min_team_size INT NOT NULL DEFAULT 0;
+ALTER TABLE contests ADD COLUMN max_team_size INT NOT NULL DEFAULT 0;
+
+CREATE TABLE teams (
+    id TEXT PRIMARY KEY,
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    invite_code TEXT NOT NULL UNIQUE, -- Shared by the leader for members to join
+    leader_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at BIGINT NOT NULL,
+    registered_at BIGINT, -- NULL until the leader registers the team
+    UNIQUE (contest_id, name)
+);
+
+-- A user is in at most one team per contest
+CREATE TABLE team_members (
+    team_id TEXT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at BIGINT NOT NULL,
+    PRIMARY KEY (team_id, user_id),
+    UNIQUE (contest_id, user_id)
+);
+
+-- Submissions in team contests count for the team, user_id is the submitting member
+ALTER TABLE submissions ADD COLUMN team_id TEXT REFERENCES teams(id) ON DELETE SET NULL;
+
+CREATE TABLE team_rankings (
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    team_id TEXT NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    score INT NOT NULL DEFAULT 0,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    disqualified BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (contest_id, team_id)
+);
+
+-- Public team leaderboard, hidden and disqualified teams are left out of the ranks
+CREATE MATERIALIZED VIEW team_ranking_mv AS
+SELECT
+    contest_id,
+    team_id,
+    score,
+    RANK() OVER (PARTITION BY contest_id ORDER BY score DESC) AS rank
+FROM team_rankings
+WHERE NOT hidden AND NOT disqualified;
+
+-- Required to refresh the view concurrently
+CREATE UNIQUE INDEX team_ranking_mv_contest_team_idx ON team_ranking_mv (contest_id, team_id);
+
+-- Code submissions are judged outside the app, credit the team with the problem's score
+-- the first time one of its members gets it accepted
+CREATE FUNCTION team_rankings_credit_code() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.type <> 'code' OR NEW.team_id IS NULL OR NEW.practice
+        OR NEW.status <> 'accepted' OR OLD.status = 'accepted' THEN
+        RETURN NEW;
+    END IF;
+
+    -- Serializes credits for the team so two accepted members can't both score the problem
+    PERFORM 1 FROM team_rankings
+    WHERE contest_id = NEW.contest_id AND team_id = NEW.team_id
+    FOR UPDATE;
+
+    IF EXISTS (
+        SELECT 1 FROM submissions
+        WHERE contest_id = NEW.contest_id AND team_id = NEW.team_id AND problem_id = NEW.problem_id
+            AND type = 'code' AND status = 'accepted' AND NOT practice AND id <> NEW.id
+    ) THEN
+        RETURN NEW;
+    END IF;
+
+    UPDATE team_rankings tr
+    SET score = tr.score + COALESCE(cp.score, p.score)
+    FROM contest_problems cp
+    JOIN problems p ON p.id = cp.problem_id
+    WHERE tr.contest_id = NEW.contest_id AND tr.team_id = NEW.team_id
+        AND cp.contest_id = NEW.contest_id AND cp.problem_id = NEW.problem_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER team_rankings_credit_code
+AFTER UPDATE OF status ON submissions
+FOR EACH ROW EXECUTE FUNCTION team_rankings_credit_code();
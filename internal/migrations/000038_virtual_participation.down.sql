@@ -0,0 +1,2 @@
+ALTER TABLE submissions DROP COLUMN virtual;
+DROP TABLE IF EXISTS virtual_participations;
@@ -0,0 +1,10 @@
+-- Users replaying an ended contest under its original duration
+CREATE TABLE virtual_participations (
+    contest_id TEXT NOT NULL REFERENCES contests(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at BIGINT NOT NULL, -- Unix ms
+    PRIMARY KEY (contest_id, user_id)
+);
+
+-- Virtual submissions are also practice submissions, so they never count for official rankings
+ALTER TABLE submissions ADD COLUMN virtual BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,11 @@
+CREATE TABLE admin (
+    user_id VARCHAR(255) PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO admin (user_id)
+SELECT DISTINCT user_id FROM admin_roles WHERE role = 'super_admin' AND contest_id IS NULL;
+
+DROP TABLE IF EXISTS admin_roles;
+DROP TYPE IF EXISTS admin_role;
@@ -0,0 +1,20 @@
+CREATE TYPE admin_role AS ENUM ('super_admin', 'contest_manager', 'problem_setter', 'reviewer', 'read_only');
+
+-- Roles granted to admins, either for every contest or scoped to a single one
+CREATE TABLE admin_roles (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role admin_role NOT NULL,
+    contest_id TEXT REFERENCES contests(id) ON DELETE CASCADE, -- NULL for a global grant
+    granted_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    granted_at BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_admin_roles_grant ON admin_roles(user_id, role, COALESCE(contest_id, ''));
+
+-- Existing admins keep full access
+INSERT INTO admin_roles (id, user_id, role, granted_at)
+SELECT gen_random_uuid()::TEXT, user_id, 'super_admin', EXTRACT(EPOCH FROM created_at)::BIGINT
+FROM admin;
+
+DROP TABLE admin;
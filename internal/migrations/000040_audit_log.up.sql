@@ -0,0 +1,28 @@
+-- Append-only log of admin mutations. Entries are only ever removed by the retention purge.
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id TEXT NOT NULL, -- Firebase UID, no foreign key so entries outlive the user
+    action TEXT NOT NULL, -- Method and route, e.g. DELETE /admin/contest/:id
+    target JSONB NOT NULL DEFAULT '{}', -- Route parameters
+    contest_id TEXT, -- No foreign key so entries outlive the contest
+    before JSONB,
+    after JSONB,
+    changes JSONB, -- Top-level fields of after that differ from before
+    request_id TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at DESC);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor_id, created_at DESC);
+CREATE INDEX idx_audit_log_contest ON audit_log(contest_id, created_at DESC);
+
+CREATE FUNCTION audit_log_reject_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE ON audit_log
+FOR EACH ROW EXECUTE FUNCTION audit_log_reject_update();
@@ -0,0 +1,5 @@
+-- Banned accounts are also disabled in Firebase, these columns keep who banned them and why
+ALTER TABLE users
+    ADD COLUMN banned_at BIGINT, -- Unix timestamp, NULL unless banned
+    ADD COLUMN ban_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN banned_by TEXT REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,16 @@
+-- Suspensions block users from registering and submitting, everywhere or in a single contest.
+-- Lifted suspensions are kept for later review.
+CREATE TABLE user_suspensions (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    contest_id TEXT REFERENCES contests(id) ON DELETE CASCADE, -- NULL for a global suspension
+    reason TEXT NOT NULL,
+    expires_at BIGINT, -- Unix ms, NULL for an indefinite suspension
+    created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at BIGINT NOT NULL,
+    lifted_at BIGINT,
+    lifted_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    lift_reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_user_suspensions_user ON user_suspensions(user_id, created_at DESC);
@@ -0,0 +1,28 @@
+package models
+
+// Announcement is a message from the admins to everyone registered in a contest,
+// optionally about a single problem
+type Announcement struct {
+	ID        string `json:"id"` // UUID as string
+	ContestID string `json:"contest_id"`
+	ProblemID string `json:"problem_id,omitempty"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`       // Markdown
+	CreatedBy string `json:"created_by"` // Firebase UID of the admin
+	CreatedAt int64  `json:"created_at"` // Unix timestamp
+	UpdatedAt int64  `json:"updated_at"` // Unix timestamp
+}
+
+type AnnouncementEventType string
+
+const (
+	AnnouncementCreated AnnouncementEventType = "created"
+	AnnouncementUpdated AnnouncementEventType = "updated"
+	AnnouncementDeleted AnnouncementEventType = "deleted"
+)
+
+// AnnouncementEvent is delivered to the live subscribers of a contest when its announcements change
+type AnnouncementEvent struct {
+	Type         AnnouncementEventType `json:"type"`
+	Announcement Announcement          `json:"announcement"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "encoding/json"
+
+// AuditEntry records a successful mutation made through the admin API
+type AuditEntry struct {
+	ID        int64                  `json:"id"`
+	ActorID   string                 `json:"actor_id"`             // Firebase UID of the admin
+	Action    string                 `json:"action"`               // Method and route, e.g. DELETE /admin/contest/:id
+	Target    map[string]string      `json:"target"`               // Route parameters
+	ContestID string                 `json:"contest_id,omitempty"` // Contest the action was on, if any
+	Before    json.RawMessage        `json:"before,omitempty"`     // State before the action, for actions that snapshot it
+	After     json.RawMessage        `json:"after,omitempty"`      // JSON request body
+	Changes   map[string]AuditChange `json:"changes,omitempty"`    // Fields of After that differ from Before
+	RequestID string                 `json:"request_id"`
+	IP        string                 `json:"ip"`
+	CreatedAt int64                  `json:"created_at"` // Unix timestamp
+}
+
+// AuditChange is a field changed by an audited action
+type AuditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
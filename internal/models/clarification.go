@@ -0,0 +1,23 @@
+package models
+
+type ClarificationStatus string
+
+const (
+	ClarificationPending  ClarificationStatus = "pending"
+	ClarificationAnswered ClarificationStatus = "answered"
+)
+
+// Clarification is a question a contestant asks the admins during a contest
+type Clarification struct {
+	ID         string              `json:"id"` // UUID as string
+	ContestID  string              `json:"contest_id"`
+	ProblemID  string              `json:"problem_id,omitempty"` // Empty for questions about the whole contest
+	UserID     string              `json:"user_id"`
+	Question   string              `json:"question"`
+	Status     ClarificationStatus `json:"status"`
+	Answer     string              `json:"answer,omitempty"`
+	Public     bool                `json:"public"`                // Answer was broadcast to everyone in the contest
+	AnsweredBy string              `json:"answered_by,omitempty"` // Firebase UID of the admin
+	CreatedAt  int64               `json:"created_at"`            // Unix timestamp
+	AnsweredAt int64               `json:"answered_at,omitempty"` // Unix timestamp
+}
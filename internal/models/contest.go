@@ -1,16 +1,123 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Contest struct {
-	ID                    string `json:"id"` // UUID as string
-	Name                  string `json:"name"`
-	Description           string `json:"description"`             // base64 encoded
-	RegistrationStartTime int64  `json:"registration_start_time"` // Unix timestamp
-	RegistrationEndTime   int64  `json:"registration_end_time"`   // Unix timestamp
-	StartTime             int64  `json:"start_time"`              // Unix timestamp
-	EndTime               int64  `json:"end_time"`                // Unix timestamp
-	EligibleTo            []int  `json:"eligible_to"`             // Student year restriction
+	ID                    string        `json:"id"` // UUID as string
+	Name                  string        `json:"name"`
+	Description           string        `json:"description"`             // base64 encoded
+	RegistrationStartTime int64         `json:"registration_start_time"` // Unix timestamp
+	RegistrationEndTime   int64         `json:"registration_end_time"`   // Unix timestamp
+	StartTime             int64         `json:"start_time"`              // Unix timestamp
+	EndTime               int64         `json:"end_time"`                // Unix timestamp
+	EligibleTo            []int         `json:"eligible_to"`             // Student year restriction
+	Status                ContestStatus `json:"status"`
+	IsTemplate            bool          `json:"is_template"`      // Templates are never published, only instantiated
+	Duration              int64         `json:"duration"`         // Seconds per personal attempt, 0 if the contest is not timed
+	ClampScore            bool          `json:"clamp_score"`      // Total score never goes below zero
+	ShuffleProblems       bool          `json:"shuffle_problems"` // Each participant sees the problems in their own order
+	ShuffleOptions        bool          `json:"shuffle_options"`  // Each participant sees MCQ options in their own order
+	PracticeMode          bool          `json:"practice_mode"`    // After the end anyone can view the problems and submit unranked
+	MaxParticipants       int           `json:"max_participants"` // Seats in the contest, 0 for no limit
+	Departments           []string      `json:"departments"`      // Departments that may register, empty for any
+	USNPattern            string        `json:"usn_pattern"`      // Regular expression the USN must match, empty for any
+	InviteOnly            bool          `json:"invite_only"`      // Only users on the allow list may register
+	Private               bool          `json:"private"`          // Not listed, users register with an invite code or from the allow list
+	TeamMode              bool          `json:"team_mode"`        // Users register, submit and are ranked as teams
+	MinTeamSize           int           `json:"min_team_size"`    // Members a team needs to register, in team contests
+	MaxTeamSize           int           `json:"max_team_size"`    // Members a team may have, in team contests
+}
+
+type ContestAccess string
+
+const (
+	AccessAllow ContestAccess = "allow" // Eligible regardless of the contest's rules
+	AccessDeny  ContestAccess = "deny"  // Never eligible
+)
+
+// AccessListEntry explicitly allows or denies a user registering for a contest
+type AccessListEntry struct {
+	ContestID string        `json:"contest_id"`
+	UserID    string        `json:"user_id"`
+	Access    ContestAccess `json:"access"`
+	AddedBy   string        `json:"added_by,omitempty"` // Firebase UID of the admin
+	AddedAt   int64         `json:"added_at"`           // Unix timestamp
+}
+
+// InviteCode puts the users redeeming it on a contest's allow list
+type InviteCode struct {
+	Code      string `json:"code"`
+	ContestID string `json:"contest_id"`
+	MaxUses   int    `json:"max_uses"` // 1 for single use, 0 for no limit
+	Uses      int    `json:"uses"`
+	ExpiresAt int64  `json:"expires_at"`           // Unix timestamp in milliseconds, 0 if the code never expires
+	CreatedBy string `json:"created_by,omitempty"` // Firebase UID of the admin
+	CreatedAt int64  `json:"created_at"`           // Unix timestamp
+}
+
+// IsExpired reports whether the code can no longer be redeemed at now, in Unix milliseconds
+func (i *InviteCode) IsExpired(now int64) bool {
+	return i.ExpiresAt > 0 && i.ExpiresAt < now
+}
+
+// IsExhausted reports whether every use of the code has been redeemed
+func (i *InviteCode) IsExhausted() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// WaitlistEntry is a user waiting for a seat in a full contest
+type WaitlistEntry struct {
+	UserID   string `json:"user_id"`
+	Position int    `json:"position"`  // 1 is promoted first
+	JoinedAt int64  `json:"joined_at"` // Unix timestamp
+}
+
+// ContestSection groups problems of a contest, such as "Aptitude" or "Coding"
+type ContestSection struct {
+	ID           string `json:"id"` // UUID as string
+	ContestID    string `json:"contest_id"`
+	Name         string `json:"name"`
+	Instructions string `json:"instructions"` // Markdown
+	Position     int    `json:"position"`
+}
+
+// ContestPool draws a number of problems for each participant out of the problems tagged with it
+type ContestPool struct {
+	ContestID string `json:"contest_id"`
+	Tag       string `json:"tag"`
+	Draw      int    `json:"draw"`
+}
+
+// ProblemSetEntry is a problem drawn for a participant, in the position it is presented at
+type ProblemSetEntry struct {
+	ContestID   string `json:"contest_id"`
+	UserID      string `json:"user_id"`
+	ProblemID   string `json:"problem_id"`
+	Position    int    `json:"position"`
+	OptionOrder []int  `json:"option_order,omitempty"` // Option IDs in presentation order, empty if not shuffled
+}
+
+type ContestStatus string
+
+const (
+	ContestDraft     ContestStatus = "draft"     // Only visible to admins
+	ContestPublished ContestStatus = "published" // Listed publicly
+	ContestArchived  ContestStatus = "archived"  // Listed under past contests
+)
+
+// contestStatusTransitions lists the statuses a contest may move to from each status
+var contestStatusTransitions = map[ContestStatus][]ContestStatus{
+	ContestDraft:     {ContestPublished},
+	ContestPublished: {ContestDraft, ContestArchived},
+	ContestArchived:  {ContestPublished},
+}
+
+// CanTransitionTo reports whether a contest with status s may move to the next status
+func (s ContestStatus) CanTransitionTo(next ContestStatus) bool {
+	return slices.Contains(contestStatusTransitions[s], next)
 }
 
 type ContestRegistrationStatus string
@@ -48,3 +155,23 @@ func (c *Contest) GetRunningStatus() ContestRunningStatus {
 	}
 	return ContestRunningClosed
 }
+
+// IsTimed reports whether each user gets a personal attempt window within the contest
+func (c *Contest) IsTimed() bool {
+	return c.Duration > 0
+}
+
+// GetVirtualDuration returns how long a virtual participation lasts in milliseconds,
+// the attempt duration of timed contests or the length of the contest otherwise
+func (c *Contest) GetVirtualDuration() int64 {
+	if c.IsTimed() {
+		return c.Duration * 1000
+	}
+	return c.EndTime - c.StartTime
+}
+
+// GetAttemptDeadline returns when an attempt started at startedAt ends,
+// which is never later than the end of the contest
+func (c *Contest) GetAttemptDeadline(startedAt int64) int64 {
+	return min(startedAt+c.Duration*1000, c.EndTime)
+}
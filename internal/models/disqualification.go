@@ -0,0 +1,42 @@
+package models
+
+type DisqualificationAction string
+
+const (
+	DisqualifyAction DisqualificationAction = "disqualify"
+	ReinstateAction  DisqualificationAction = "reinstate"
+)
+
+// Disqualification is a single entry in the append-only disqualification history
+// of a user in a contest. The latest entry decides the current state.
+type Disqualification struct {
+	ID        string                 `json:"id"` // UUID as string
+	ContestID string                 `json:"contest_id"`
+	UserID    string                 `json:"user_id"`
+	Action    DisqualificationAction `json:"action"`
+	Reason    string                 `json:"reason"`
+	Evidence  []string               `json:"evidence"`   // Submission IDs
+	AdminID   string                 `json:"admin_id"`   // Firebase UID of the acting admin
+	CreatedAt int64                  `json:"created_at"` // Unix timestamp
+}
+
+type AppealStatus string
+
+const (
+	AppealPending  AppealStatus = "pending"
+	AppealAccepted AppealStatus = "accepted"
+	AppealRejected AppealStatus = "rejected"
+)
+
+type DisqualificationAppeal struct {
+	ID                 string       `json:"id"` // UUID as string
+	DisqualificationID string       `json:"disqualification_id"`
+	ContestID          string       `json:"contest_id"`
+	UserID             string       `json:"user_id"`
+	Message            string       `json:"message"`
+	Status             AppealStatus `json:"status"`
+	Response           string       `json:"response,omitempty"`
+	ResolvedBy         string       `json:"resolved_by,omitempty"`
+	CreatedAt          int64        `json:"created_at"`            // Unix timestamp
+	ResolvedAt         int64        `json:"resolved_at,omitempty"` // Unix timestamp
+}
@@ -0,0 +1,39 @@
+package models
+
+// RecruitmentDrive groups contests run as successive rounds of a recruitment, such as
+// an MCQ round followed by a coding round
+type RecruitmentDrive struct {
+	ID          string       `json:"id"` // UUID as string
+	Name        string       `json:"name"`
+	Description string       `json:"description"`          // Markdown
+	CreatedBy   string       `json:"created_by,omitempty"` // Firebase UID of the admin
+	CreatedAt   int64        `json:"created_at"`           // Unix timestamp
+	Rounds      []DriveRound `json:"rounds"`
+}
+
+// DriveRound is a contest of a drive. Only users shortlisted in the previous round may register for it.
+type DriveRound struct {
+	ContestID string        `json:"contest_id"`
+	Position  int           `json:"position"` // 1 for the first round
+	Name      string        `json:"name"`
+	StartTime int64         `json:"start_time"` // Unix timestamp
+	EndTime   int64         `json:"end_time"`   // Unix timestamp
+	Status    ContestStatus `json:"status"`
+}
+
+// DriveRoundProgress is how far a candidate got in a round
+type DriveRoundProgress struct {
+	DriveRound
+	Unlocked    bool `json:"unlocked"` // The candidate may register, the first round or shortlisted in the previous one
+	Registered  bool `json:"registered"`
+	Attempted   bool `json:"attempted"` // Started the attempt or submitted
+	Shortlisted bool `json:"shortlisted"`
+}
+
+// DriveFunnelRound counts the candidates reaching each stage of a round
+type DriveFunnelRound struct {
+	DriveRound
+	Registered  int `json:"registered"`
+	Attempted   int `json:"attempted"`
+	Shortlisted int `json:"shortlisted"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type UpsertAnnouncementRequest struct {
+	Title     string `json:"title" validate:"required"`
+	Body      string `json:"body" validate:"required"` // Markdown
+	ProblemID string `json:"problem_id"`               // Empty for announcements about the whole contest
+}
@@ -0,0 +1,10 @@
+package dto
+
+type ListAuditLogRequest struct {
+	ActorID   string `query:"actor_id"`
+	Action    string `query:"action"` // Matches any part of the action, e.g. DELETE or /contest/:id
+	ContestID string `query:"contest_id"`
+	From      int64  `query:"from" validate:"min=0"` // Unix timestamp
+	To        int64  `query:"to" validate:"min=0"`   // Unix timestamp
+	Page      int    `query:"page" validate:"min=0"`
+}
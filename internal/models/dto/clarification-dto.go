@@ -0,0 +1,18 @@
+package dto
+
+import "app/internal/models"
+
+type CreateClarificationRequest struct {
+	ProblemID string `json:"problem_id"` // Empty for questions about the whole contest
+	Question  string `json:"question" validate:"required,max=2000"`
+}
+
+type AnswerClarificationRequest struct {
+	Answer    string `json:"answer" validate:"required"`
+	Broadcast bool   `json:"broadcast"` // Show the question and answer to everyone in the contest
+}
+
+type ListClarificationsRequest struct {
+	Status models.ClarificationStatus `query:"status" validate:"omitempty,oneof=pending answered"`
+	Page   int                        `query:"page" validate:"min=0"`
+}
@@ -1,25 +1,77 @@
 package dto
 
-import "app/internal/models"
+import (
+	"app/internal/eligibility"
+	"app/internal/models"
+)
 
 // GetContestResponse represents the response for getting contest details
 type GetContestResponse struct {
 	models.Contest
-	IsRegistered *bool `json:"is_registered,omitempty"` // Whether the user is registered for the contest
+	IsRegistered     *bool                   `json:"is_registered,omitempty"`     // Whether the user is registered for the contest
+	Disqualification *DisqualificationStatus `json:"disqualification,omitempty"`  // Set when the user is currently disqualified
+	Attempt          *ContestAttempt         `json:"attempt,omitempty"`           // Set once the user started a timed contest
+	WaitlistPosition *int                    `json:"waitlist_position,omitempty"` // Set while the user waits for a seat, 1 is promoted first
+	Eligibility      *eligibility.Result     `json:"eligibility,omitempty"`       // Set while an unregistered user could still register
+	Virtual          *ContestAttempt         `json:"virtual,omitempty"`           // Set once the user started a virtual participation
+}
+
+// ContestAttempt is the user's personal attempt window in a timed contest
+type ContestAttempt struct {
+	StartedAt     int64 `json:"started_at"`     // Unix timestamp
+	Deadline      int64 `json:"deadline"`       // Unix timestamp
+	RemainingTime int64 `json:"remaining_time"` // Seconds left, 0 once the deadline has passed
 }
 
 type UpsertContestRequest struct {
-	Name                  string `json:"name" validate:"required"`
-	Description           string `json:"description" validate:"required"` // base64 encoded
-	RegistrationStartTime int64  `json:"registration_start_time" validate:"required"`
-	RegistrationEndTime   int64  `json:"registration_end_time" validate:"required,gtfield=RegistrationStartTime"`
-	StartTime             int64  `json:"start_time" validate:"required,gtfield=RegistrationStartTime"`
-	EndTime               int64  `json:"end_time" validate:"required,gtfield=StartTime"`
-	EligibleTo            []int  `json:"eligible_to" validate:"required,dive,oneof=1 2 3"` // Student year restriction
+	Name                  string   `json:"name" validate:"required"`
+	Description           string   `json:"description" validate:"required"` // base64 encoded
+	RegistrationStartTime int64    `json:"registration_start_time" validate:"required"`
+	RegistrationEndTime   int64    `json:"registration_end_time" validate:"required,gtfield=RegistrationStartTime"`
+	StartTime             int64    `json:"start_time" validate:"required,gtfield=RegistrationStartTime"`
+	EndTime               int64    `json:"end_time" validate:"required,gtfield=StartTime"`
+	EligibleTo            []int    `json:"eligible_to" validate:"required,dive,oneof=1 2 3"` // Student year restriction
+	Duration              int64    `json:"duration" validate:"min=0"`                        // Seconds per personal attempt, 0 if not timed
+	ClampScore            bool     `json:"clamp_score"`                                      // Keeps the total score from going below zero
+	ShuffleProblems       bool     `json:"shuffle_problems"`
+	ShuffleOptions        bool     `json:"shuffle_options"`
+	PracticeMode          bool     `json:"practice_mode"`                     // Opens the problems to everyone for unranked submissions after the end
+	MaxParticipants       int      `json:"max_participants" validate:"min=0"` // 0 for no limit
+	Departments           []string `json:"departments"`                       // Empty for any department
+	USNPattern            string   `json:"usn_pattern"`                       // Regular expression the whole USN must match, ignoring case
+	InviteOnly            bool     `json:"invite_only"`                       // Only users on the allow list may register
+	Private               bool     `json:"private"`                           // Not listed, users register with an invite code or from the allow list
+	TeamMode              bool     `json:"team_mode"`                         // Users register, submit and are ranked as teams
+	MinTeamSize           int      `json:"min_team_size" validate:"min=0"`    // Defaults to 1 in team contests
+	MaxTeamSize           int      `json:"max_team_size" validate:"min=0"`
+}
+
+type CreateInviteCodeRequest struct {
+	MaxUses   int   `json:"max_uses" validate:"min=0"`   // 1 for single use, 0 for no limit
+	ExpiresAt int64 `json:"expires_at" validate:"min=0"` // Unix timestamp in milliseconds, 0 if the code never expires
+}
+
+type InviteShortlistedRequest struct {
+	SourceContestID string `json:"source_contest_id" validate:"required"` // Contest whose shortlisted users are invited
+}
+
+type SetAccessRequest struct {
+	Access models.ContestAccess `json:"access" validate:"required,oneof=allow deny"`
+}
+
+type UpsertPoolRequest struct {
+	Draw int `json:"draw" validate:"required,min=1"` // Problems drawn for each participant
 }
 
 type ModifyRegistrationRequest struct {
-	Action RegisterationAction `json:"action" validate:"required,oneof=register unregister"`
+	Action     RegisterationAction `json:"action" validate:"required,oneof=register unregister"`
+	InviteCode string              `json:"invite_code"` // Redeemed before registering, required for private contests unless invited
+}
+
+// ModifyRegistrationResponse is returned when a registration joins the waitlist of a full contest
+type ModifyRegistrationResponse struct {
+	Waitlisted       bool `json:"waitlisted"`
+	WaitlistPosition int  `json:"waitlist_position"`
 }
 
 type RegisterationAction string
@@ -28,3 +80,38 @@ const (
 	RegisterAction   RegisterationAction = "register"
 	UnregisterAction RegisterationAction = "unregister"
 )
+
+type UpdateContestStatusRequest struct {
+	Status models.ContestStatus `json:"status" validate:"required,oneof=draft published archived"`
+}
+
+type AdminListContestsRequest struct {
+	Status models.ContestStatus `query:"status" validate:"omitempty,oneof=draft published archived"`
+	Page   int                  `query:"page" validate:"min=0"`
+}
+
+type CloneContestRequest struct {
+	Name      string `json:"name"`                           // Defaults to the source contest name
+	StartTime int64  `json:"start_time" validate:"required"` // All contest times are shifted by the same offset
+}
+
+type SaveTemplateRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type InstantiateTemplateRequest struct {
+	Name                  string `json:"name" validate:"required"`
+	RegistrationStartTime int64  `json:"registration_start_time" validate:"required"`
+	RegistrationEndTime   int64  `json:"registration_end_time" validate:"required,gtfield=RegistrationStartTime"`
+	StartTime             int64  `json:"start_time" validate:"required,gtfield=RegistrationStartTime"`
+	EndTime               int64  `json:"end_time" validate:"required,gtfield=StartTime"`
+	EligibleTo            []int  `json:"eligible_to" validate:"required,dive,oneof=1 2 3"` // Student year restriction
+}
+
+type ImportContestResponse struct {
+	ContestID string   `json:"contest_id"`
+	DryRun    bool     `json:"dry_run"`
+	Problems  int      `json:"problems"`
+	TestCases int      `json:"test_cases"`
+	Conflicts []string `json:"conflicts"` // Existing resources that would be overwritten
+}
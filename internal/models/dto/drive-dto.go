@@ -0,0 +1,18 @@
+package dto
+
+import "app/internal/models"
+
+type UpsertDriveRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"` // Markdown
+}
+
+type SetDriveRoundsRequest struct {
+	ContestIDs []string `json:"contest_ids" validate:"unique,dive,required"` // In the order the rounds are run
+}
+
+// DriveProgressResponse is a candidate's progress through the rounds of a drive
+type DriveProgressResponse struct {
+	models.RecruitmentDrive
+	Progress []models.DriveRoundProgress `json:"progress"`
+}
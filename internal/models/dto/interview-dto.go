@@ -0,0 +1,33 @@
+package dto
+
+import "app/internal/models"
+
+type UpsertInterviewRequest struct {
+	UserID       string   `json:"user_id" validate:"required"`
+	Title        string   `json:"title" validate:"required"`
+	ScheduledAt  int64    `json:"scheduled_at" validate:"required"`   // Unix timestamp in milliseconds
+	Duration     int      `json:"duration" validate:"required,min=1"` // Minutes
+	Location     string   `json:"location"`                           // Room or meeting link
+	Interviewers []string `json:"interviewers" validate:"required,min=1,unique,dive,required"`
+}
+
+type SubmitFeedbackRequest struct {
+	ProblemSolving int    `json:"problem_solving" validate:"required,min=1,max=5"`
+	Coding         int    `json:"coding" validate:"required,min=1,max=5"`
+	Communication  int    `json:"communication" validate:"required,min=1,max=5"`
+	Comments       string `json:"comments"`
+}
+
+type SetDecisionRequest struct {
+	Decision models.CandidateDecisionType `json:"decision" validate:"required,oneof=selected rejected on_hold"`
+	Notes    string                       `json:"notes"`
+}
+
+// CandidateInterview is an interview slot as shown to the candidate
+type CandidateInterview struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	ScheduledAt int64  `json:"scheduled_at"` // Unix timestamp in milliseconds
+	Duration    int    `json:"duration"`     // Minutes
+	Location    string `json:"location"`
+}
@@ -3,17 +3,94 @@ package dto
 import "app/internal/models"
 
 type ProblemOverview struct {
-	ID    string                `json:"id"`
-	Name  string                `json:"name"`
-	Score int                   `json:"score"`
-	Type  models.SubmissionType `json:"type"`
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	Score     int                   `json:"score"`
+	Type      models.SubmissionType `json:"type"`
+	Position  int                   `json:"position"` // Order the problem is presented in, starting at 1
+	Label     string                `json:"label"`
+	SectionID string                `json:"section_id,omitempty"`
+	Section   string                `json:"section,omitempty"` // Section name
 }
 
 type GetProblemStatementResponse struct {
-	ProblemID   string                `json:"problem_id"`
-	ContestID   string                `json:"contest_id"`
-	Name        string                `json:"name"`
-	Description string                `json:"description"`
-	Score       int                   `json:"score"`
-	Type        models.SubmissionType `json:"type"`
+	ProblemID      string                 `json:"problem_id"`
+	ContestID      string                 `json:"contest_id"`
+	Name           string                 `json:"name"`
+	Label          string                 `json:"label"`
+	SectionID      string                 `json:"section_id,omitempty"`
+	Description    string                 `json:"description"`
+	Score          int                    `json:"score"`
+	Type           models.SubmissionType  `json:"type"`
+	MultipleChoice bool                   `json:"multiple_choice"`
+	NegativeMarks  int                    `json:"negative_marks"`
+	PartialCredit  bool                   `json:"partial_credit"`
+	Options        []models.ProblemOption `json:"options,omitempty"` // Never includes the answer
+}
+
+// BankProblem is a problem of the bank with how it fared across contests
+type BankProblem struct {
+	models.Problem
+	Stats models.ProblemStats `json:"stats"`
+}
+
+type ListBankProblemsRequest struct {
+	Tag        string            `query:"tag"`
+	Difficulty models.Difficulty `query:"difficulty" validate:"omitempty,oneof=easy medium hard"`
+	Page       int               `query:"page" validate:"min=0"`
+}
+
+type AttachProblemRequest struct {
+	ProblemID string `json:"problem_id" validate:"required"`
+	Score     *int   `json:"score" validate:"omitempty,min=1"` // Overrides the problem's score in this contest
+	Label     string `json:"label"`                            // Defaults to the next letter
+	SectionID string `json:"section_id"`
+	Pool      string `json:"pool"`
+}
+
+type UpsertEditorialRequest struct {
+	Content string `json:"content" validate:"required"` // Markdown
+}
+
+type CreateSolutionRequest struct {
+	Language    string `json:"language" validate:"required"`
+	Code        string `json:"code" validate:"required"`
+	Explanation string `json:"explanation"` // Markdown
+}
+
+// ProblemEditorialResponse is the editorial and reference solutions of a problem after the contest
+type ProblemEditorialResponse struct {
+	Editorial *models.ProblemEditorial   `json:"editorial"` // nil if none was written
+	Solutions []models.ReferenceSolution `json:"solutions"`
+}
+
+type ReorderProblemsRequest struct {
+	ProblemIDs []string   `json:"problem_ids" validate:"required,min=1"`                  // Every problem of the contest, in the new order
+	LabelStyle LabelStyle `json:"label_style" validate:"omitempty,oneof=letters numbers"` // Relabels the problems, labels are kept if empty
+}
+
+type LabelStyle string
+
+const (
+	LetterLabels LabelStyle = "letters" // A, B, C...
+	NumberLabels LabelStyle = "numbers" // Q1, Q2, Q3...
+)
+
+type UpsertSectionRequest struct {
+	Name         string `json:"name" validate:"required"`
+	Instructions string `json:"instructions"`              // Markdown
+	Position     int    `json:"position" validate:"min=0"` // 0 places a new section last
+}
+
+type CreateTestCaseRequest struct {
+	Input    string `json:"input" validate:"required"`  // Base64 encoded
+	Output   string `json:"output" validate:"required"` // Base64 encoded
+	IsSample bool   `json:"is_sample"`
+}
+
+type UpsertOptionRequest struct {
+	Text     string `json:"text" validate:"required"` // Markdown
+	Image    string `json:"image"`
+	Position int    `json:"position" validate:"min=0"` // 0 places a new option last
+	Weight   int    `json:"weight" validate:"min=0"`   // Share of the partial credit, defaults to 1
 }
@@ -1,6 +1,31 @@
 package dto
 
+import "app/internal/models"
+
 type UpdateLeaderboardUserRequest struct {
-	Hidden       *bool `json:"hidden"`
-	Disqualified *bool `json:"disqualified"`
+	Hidden       *bool    `json:"hidden"`
+	Disqualified *bool    `json:"disqualified"`
+	Reason       string   `json:"reason"`   // Required when disqualified is provided
+	Evidence     []string `json:"evidence"` // Optional submission IDs backing the disqualification
+}
+
+// DisqualificationStatus is shown to a disqualified user on their contest view
+type DisqualificationStatus struct {
+	Reason         string                         `json:"reason"`
+	DisqualifiedAt int64                          `json:"disqualified_at"`
+	Appeal         *models.DisqualificationAppeal `json:"appeal,omitempty"` // Latest appeal against this disqualification
+}
+
+type CreateAppealRequest struct {
+	Message string `json:"message" validate:"required"`
+}
+
+type ResolveAppealRequest struct {
+	Status   models.AppealStatus `json:"status" validate:"required,oneof=accepted rejected"`
+	Response string              `json:"response" validate:"required"`
+}
+
+type ListAppealsRequest struct {
+	Status models.AppealStatus `query:"status" validate:"omitempty,oneof=pending accepted rejected"`
+	Page   int                 `query:"page" validate:"min=0"`
 }
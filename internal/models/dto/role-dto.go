@@ -0,0 +1,9 @@
+package dto
+
+import "app/internal/models"
+
+type GrantRoleRequest struct {
+	UserID    string      `json:"user_id" validate:"required"`
+	Role      models.Role `json:"role" validate:"required,oneof=super_admin contest_manager problem_setter reviewer read_only"`
+	ContestID string      `json:"contest_id"` // Scope the role to a single contest, empty for every contest
+}
@@ -11,6 +11,11 @@ type SubmitSubmissionRequest struct {
 	Type      models.SubmissionType `json:"type" validate:"required"`
 }
 
+// GetContestID lets suspension checks find the contest of a submission
+func (r *SubmitSubmissionRequest) GetContestID() string {
+	return r.ContestID
+}
+
 type SubmitSubmissionResponse struct {
 	SubmissionID string `json:"submission_id"`
 }
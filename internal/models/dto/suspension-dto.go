@@ -0,0 +1,17 @@
+package dto
+
+type CreateSuspensionRequest struct {
+	Reason    string `json:"reason" validate:"required"`
+	ContestID string `json:"contest_id"`                  // Suspend only from this contest, empty for everywhere
+	ExpiresAt int64  `json:"expires_at" validate:"min=0"` // Unix timestamp in milliseconds, zero for indefinitely
+}
+
+type LiftSuspensionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ListSuspensionsRequest struct {
+	UserID    string `query:"user_id"`
+	ContestID string `query:"contest_id"`
+	Page      int    `query:"page" validate:"min=0"`
+}
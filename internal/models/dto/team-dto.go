@@ -0,0 +1,9 @@
+package dto
+
+type CreateTeamRequest struct {
+	Name string `json:"name" validate:"required,max=64"`
+}
+
+type JoinTeamRequest struct {
+	InviteCode string `json:"invite_code" validate:"required"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "app/internal/models"
+
 type CreateUserRequest struct {
 	Name         string `json:"name" validate:"required"`
 	USN          string `json:"usn" validate:"required"`
@@ -13,3 +15,53 @@ type UpdateUserProfileRequest struct {
 	MobileNumber string `json:"mobile_number" validate:"required"`
 	Department   string `json:"department" validate:"required"`
 }
+
+type AdminListUsersRequest struct {
+	Query       string `query:"q"` // Matches any part of the name, USN or email
+	Name        string `query:"name"`
+	USN         string `query:"usn"`
+	Email       string `query:"email"`
+	Department  string `query:"department"`
+	CurrentYear int    `query:"current_year" validate:"min=0,max=3"`
+	Status      string `query:"status" validate:"omitempty,oneof=active banned"`
+	Page        int    `query:"page" validate:"min=0"`
+}
+
+// AdminUpdateUserRequest edits a user's profile, including the USN and year users cannot change themselves
+type AdminUpdateUserRequest struct {
+	Name         string `json:"name" validate:"required"`
+	USN          string `json:"usn" validate:"required"`
+	MobileNumber string `json:"mobile_number" validate:"required"`
+	CurrentYear  int    `json:"current_year" validate:"required,min=1,max=3"`
+	Department   string `json:"department" validate:"required"`
+}
+
+type BanUserRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// AdminUser is a user as shown to admins
+type AdminUser struct {
+	ID string `json:"id"` // Firebase UID
+	models.User
+	BannedAt  int64  `json:"banned_at,omitempty"` // Unix timestamp, unset unless banned
+	BanReason string `json:"ban_reason,omitempty"`
+	BannedBy  string `json:"banned_by,omitempty"` // Firebase UID of the admin
+}
+
+// UserRegistration is a contest the user registered for, with their standing in it
+type UserRegistration struct {
+	ContestID    string `json:"contest_id"`
+	ContestName  string `json:"contest_name"`
+	RegisteredAt int64  `json:"registered_at"` // Unix timestamp
+	Score        *int   `json:"score"`         // Unset until the user is ranked
+	Hidden       bool   `json:"hidden"`
+	Disqualified bool   `json:"disqualified"`
+	Shortlisted  bool   `json:"shortlisted"`
+}
+
+type AdminUserDetails struct {
+	AdminUser
+	Registrations []UserRegistration  `json:"registrations"`
+	Submissions   []models.Submission `json:"submissions"` // Newest first, paginated with page
+}
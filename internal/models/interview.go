@@ -0,0 +1,46 @@
+package models
+
+// Interview is a slot in which admins interview a candidate of a recruitment drive
+type Interview struct {
+	ID           string   `json:"id"` // UUID as string
+	DriveID      string   `json:"drive_id"`
+	UserID       string   `json:"user_id"`
+	Title        string   `json:"title"`        // Such as "Technical round"
+	ScheduledAt  int64    `json:"scheduled_at"` // Unix timestamp in milliseconds
+	Duration     int      `json:"duration"`     // Minutes
+	Location     string   `json:"location"`     // Room or meeting link
+	Interviewers []string `json:"interviewers"` // Firebase UIDs of the admins
+	CreatedBy    string   `json:"created_by,omitempty"`
+	CreatedAt    int64    `json:"created_at"` // Unix timestamp
+	UpdatedAt    int64    `json:"updated_at"` // Unix timestamp
+}
+
+// InterviewFeedback is the form an interviewer fills after an interview, ratings are 1 to 5
+type InterviewFeedback struct {
+	InterviewID    string `json:"interview_id"`
+	InterviewerID  string `json:"interviewer_id"`
+	ProblemSolving int    `json:"problem_solving"`
+	Coding         int    `json:"coding"`
+	Communication  int    `json:"communication"`
+	Comments       string `json:"comments"`
+	SubmittedAt    int64  `json:"submitted_at"` // Unix timestamp
+	UpdatedAt      int64  `json:"updated_at"`   // Unix timestamp
+}
+
+type CandidateDecisionType string
+
+const (
+	DecisionSelected CandidateDecisionType = "selected"
+	DecisionRejected CandidateDecisionType = "rejected"
+	DecisionOnHold   CandidateDecisionType = "on_hold"
+)
+
+// CandidateDecision is the final outcome of a candidate in a drive
+type CandidateDecision struct {
+	DriveID   string                `json:"drive_id"`
+	UserID    string                `json:"user_id"`
+	Decision  CandidateDecisionType `json:"decision"`
+	Notes     string                `json:"notes"`
+	DecidedBy string                `json:"decided_by,omitempty"` // Firebase UID of the admin
+	DecidedAt int64                 `json:"decided_at"`           // Unix timestamp
+}
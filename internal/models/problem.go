@@ -1,11 +1,170 @@
 package models
 
+import "slices"
+
 type Problem struct {
-	ID          string         `json:"id"` // UUID as string
-	ContestID   string         `json:"contest_id"`
-	Name        string         `json:"name"`
-	Description string         `json:"description"`
-	Score       int            `json:"score"`
-	Type        SubmissionType `json:"type"` // "code" or "mcq"
-	Answer      []int          `json:"answer"`
+	ID             string          `json:"id"` // UUID as string
+	ContestID      string          `json:"contest_id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Score          int             `json:"score"`
+	Type           SubmissionType  `json:"type"`     // "code" or "mcq"
+	Position       int             `json:"position"` // Order within the contest, starting at 1
+	Label          string          `json:"label"`    // Short label such as A, B or Q1
+	SectionID      string          `json:"section_id,omitempty"`
+	Answer         []int           `json:"answer"`          // IDs of the correct options
+	MultipleChoice bool            `json:"multiple_choice"` // MCQ accepts more than one option
+	NegativeMarks  int             `json:"negative_marks"`  // Deducted for a wrong MCQ answer
+	PartialCredit  bool            `json:"partial_credit"`  // Multi-select answers with only some correct options earn their share
+	Pool           string          `json:"pool,omitempty"`  // Tag of the pool the problem is drawn from, empty if everyone gets it
+	Options        []ProblemOption `json:"options,omitempty"`
+	Assets         []ProblemAsset  `json:"assets,omitempty"`
+	Tags           []string        `json:"tags,omitempty"` // Topics, for finding the problem in the bank
+	Difficulty     Difficulty      `json:"difficulty,omitempty"`
+	NextOptionID   int             `json:"-"` // ID the next new MCQ option gets, IDs of deleted options are not reused
+}
+
+type Difficulty string
+
+const (
+	Easy   Difficulty = "easy"
+	Medium Difficulty = "medium"
+	Hard   Difficulty = "hard"
+)
+
+// IsValid reports whether d is a known difficulty or unset
+func (d Difficulty) IsValid() bool {
+	switch d {
+	case "", Easy, Medium, Hard:
+		return true
+	}
+	return false
+}
+
+// ProblemStats summarizes how a problem fared across every contest that used it
+type ProblemStats struct {
+	Contests  int     `json:"contests"`   // Contests the problem is attached to
+	Attempts  int     `json:"attempts"`   // Users who submitted
+	Solves    int     `json:"solves"`     // Users with an accepted submission
+	SolveRate float64 `json:"solve_rate"` // Solves per attempt, 0 without attempts
+}
+
+// ProblemOption is a choice of an MCQ problem. IDs are unique within the problem and never reused.
+type ProblemOption struct {
+	ID       int    `json:"id"`
+	Position int    `json:"position"`
+	Text     string `json:"text"` // Markdown
+	Image    string `json:"image,omitempty"`
+	Weight   int    `json:"weight,omitempty"` // Share of the partial credit when the option is correct, hidden from participants
+}
+
+// ContainsOptions reports whether every ID refers to one of the options, with no ID repeated
+func ContainsOptions(options []ProblemOption, ids []int) bool {
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return false
+		}
+		seen[id] = true
+
+		found := false
+		for _, o := range options {
+			if o.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// GradeMCQ scores the selected options against the answer.
+//
+// An exact match earns the full score. Selecting any incorrect option, or only some
+// of the correct ones without partial credit, is wrong and costs the negative marks.
+// With partial credit, a subset of the correct options earns the weighted share of
+// the score. Nothing selected is unanswered and scores zero.
+func (p *Problem) GradeMCQ(selected []int) (SubmissionStatus, int) {
+	if len(selected) == 0 {
+		return WrongAnswer, 0
+	}
+
+	correct := make(map[int]bool, len(p.Answer))
+	for _, id := range p.Answer {
+		correct[id] = true
+	}
+
+	hits := 0
+	for _, id := range selected {
+		if !correct[id] {
+			return WrongAnswer, -p.NegativeMarks
+		}
+		hits++
+	}
+
+	if hits == len(p.Answer) {
+		return Accepted, p.Score
+	}
+
+	if !p.PartialCredit || !p.MultipleChoice {
+		return WrongAnswer, -p.NegativeMarks
+	}
+
+	earned, total := 0, 0
+	for _, o := range p.Options {
+		if !correct[o.ID] {
+			continue
+		}
+		total += o.Weight
+		if slices.Contains(selected, o.ID) {
+			earned += o.Weight
+		}
+	}
+
+	if total == 0 {
+		return WrongAnswer, 0
+	}
+
+	return WrongAnswer, p.Score * earned / total
+}
+
+// ProblemAsset is an image or attachment of a problem statement, stored in S3.
+// Descriptions reference it as asset://<name>.
+type ProblemAsset struct {
+	ID          string `json:"id"` // UUID as string
+	ProblemID   string `json:"problem_id"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	CreatedAt   int64  `json:"created_at"` // Unix timestamp
+}
+
+// TestCase input and output files are stored in S3
+type TestCase struct {
+	ID        string `json:"id"` // UUID as string
+	ProblemID string `json:"problem_id"`
+	IsSample  bool   `json:"is_sample"`
+	CreatedAt int64  `json:"created_at"` // Unix timestamp
+}
+
+// ProblemEditorial explains the solution of a problem as used in a contest, shown once the contest ends
+type ProblemEditorial struct {
+	ContestID string `json:"contest_id"`
+	ProblemID string `json:"problem_id"`
+	Content   string `json:"content"`    // Markdown
+	UpdatedAt int64  `json:"updated_at"` // Unix timestamp
+}
+
+// ReferenceSolution is an official solution to a problem as used in a contest, shown once the contest ends
+type ReferenceSolution struct {
+	ID          string `json:"id"` // UUID as string
+	ContestID   string `json:"contest_id"`
+	ProblemID   string `json:"problem_id"`
+	Language    string `json:"language"`
+	Code        string `json:"code"`
+	Explanation string `json:"explanation,omitempty"` // Markdown
+	CreatedAt   int64  `json:"created_at"`            // Unix timestamp
 }
@@ -8,3 +8,20 @@ type Ranking struct {
 	Disqualified bool   `json:"disqualified"`
 	Shortlisted  bool   `json:"shortlisted"`
 }
+
+// VirtualRanking is a row of the leaderboard shown to a virtual participant, with the official
+// participants scored as they stood at the same point of the contest
+type VirtualRanking struct {
+	UserID  string `json:"user_id"`
+	Score   int    `json:"score"`
+	Rank    int    `json:"rank"`
+	Virtual bool   `json:"virtual"` // The virtual participant themselves
+}
+
+// TeamRanking is a row of the team leaderboard of a team contest
+type TeamRanking struct {
+	TeamID   string `json:"team_id"`
+	TeamName string `json:"team_name"`
+	Score    int    `json:"score"`
+	Rank     int    `json:"rank"`
+}
@@ -0,0 +1,104 @@
+package models
+
+// Role is a named set of admin permissions
+type Role string
+
+const (
+	RoleSuperAdmin     Role = "super_admin"
+	RoleContestManager Role = "contest_manager"
+	RoleProblemSetter  Role = "problem_setter"
+	RoleReviewer       Role = "reviewer" // Also interviewers of recruitment drives
+	RoleReadOnly       Role = "read_only"
+)
+
+// Permission is an action on the admin API that a role may allow
+type Permission string
+
+const (
+	PermRead               Permission = "read"                // View contests, drives and their participants
+	PermContestsWrite      Permission = "contests:write"      // Create and edit contests, sections, templates and announcements
+	PermContestsDelete     Permission = "contests:delete"     // Delete contests
+	PermProblemsWrite      Permission = "problems:write"      // Edit problems, test cases, editorials and the problem bank
+	PermParticipantsManage Permission = "participants:manage" // Access lists, invites, leaderboards, appeals and clarifications
+	PermDrivesManage       Permission = "drives:manage"       // Recruitment drives, interview slots and final decisions
+	PermInterviewsFeedback Permission = "interviews:feedback" // Give interview feedback
+	PermRolesManage        Permission = "roles:manage"        // Grant and revoke roles
+	PermAuditRead          Permission = "audit:read"          // Search the audit log
+	PermUsersManage        Permission = "users:manage"        // Edit user profiles, ban and unban accounts
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleSuperAdmin: {
+		PermRead, PermContestsWrite, PermContestsDelete, PermProblemsWrite,
+		PermParticipantsManage, PermDrivesManage, PermInterviewsFeedback, PermRolesManage, PermAuditRead,
+		PermUsersManage,
+	},
+	RoleContestManager: {
+		PermRead, PermContestsWrite, PermContestsDelete, PermProblemsWrite,
+		PermParticipantsManage, PermDrivesManage, PermUsersManage,
+	},
+	RoleProblemSetter: {PermRead, PermProblemsWrite},
+	RoleReviewer:      {PermRead, PermInterviewsFeedback},
+	RoleReadOnly:      {PermRead},
+}
+
+// roleRanks orders roles by authority, admins may only manage users ranked below them
+var roleRanks = map[Role]int{
+	RoleSuperAdmin:     4,
+	RoleContestManager: 3,
+	RoleProblemSetter:  2,
+	RoleReviewer:       2,
+	RoleReadOnly:       1,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Allows reports whether the role includes the permission
+func (r Role) Allows(p Permission) bool {
+	for _, perm := range rolePermissions[r] {
+		if perm == p {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleGrant gives a user a role, for every contest or only the one in ContestID.
+// Scoped grants apply to the admin routes of that contest only.
+type RoleGrant struct {
+	ID        string `json:"id"` // UUID as string
+	UserID    string `json:"user_id"`
+	Role      Role   `json:"role"`
+	ContestID string `json:"contest_id,omitempty"` // Empty for a global grant
+	GrantedBy string `json:"granted_by,omitempty"` // Firebase UID of the admin
+	GrantedAt int64  `json:"granted_at"`           // Unix timestamp
+}
+
+// Grants are the roles held by an admin
+type Grants []RoleGrant
+
+// Rank returns the rank of the highest role among the grants, 0 for users without any
+func (g Grants) Rank() int {
+	rank := 0
+	for _, grant := range g {
+		rank = max(rank, roleRanks[grant.Role])
+	}
+	return rank
+}
+
+// Can reports whether the grants allow the permission, globally or for the given contest.
+// An empty contestID only matches global grants.
+func (g Grants) Can(p Permission, contestID string) bool {
+	for _, grant := range g {
+		if grant.ContestID != "" && grant.ContestID != contestID {
+			continue
+		}
+		if grant.Role.Allows(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -41,5 +41,9 @@ type Submission struct {
 	CreatedAt 		int64            `json:"created_at"`         // Unix timestamp
 	Runtime   		int64            `json:"runtime,omitempty"` 
 	Memory    		int64            `json:"memory,omitempty"`
+	Score     		*int             `json:"score,omitempty"`   // Awarded by the grader, may be negative
+	Practice  		bool             `json:"practice,omitempty"` // Made after the contest ended, not ranked
+	TeamID    		string           `json:"team_id,omitempty"`  // Team of the submitting user in team contests
+	Virtual   		bool             `json:"virtual,omitempty"`  // Made during a virtual participation, also practice
 	TestCaseResults []TestCaseResult `json:"test_case_results,omitempty"`
 }
@@ -0,0 +1,20 @@
+package models
+
+// Suspension blocks a user from registering and submitting, everywhere or in a single contest
+type Suspension struct {
+	ID         string `json:"id"` // UUID as string
+	UserID     string `json:"user_id"`
+	ContestID  string `json:"contest_id,omitempty"` // Empty for a global suspension
+	Reason     string `json:"reason"`
+	ExpiresAt  int64  `json:"expires_at,omitempty"` // Unix timestamp in milliseconds, unset for an indefinite suspension
+	CreatedBy  string `json:"created_by,omitempty"` // Firebase UID of the admin
+	CreatedAt  int64  `json:"created_at"`           // Unix timestamp
+	LiftedAt   int64  `json:"lifted_at,omitempty"`  // Unix timestamp, set once an admin lifted the suspension
+	LiftedBy   string `json:"lifted_by,omitempty"`
+	LiftReason string `json:"lift_reason,omitempty"`
+}
+
+// IsActive reports whether the suspension still applies, now being a Unix timestamp in milliseconds
+func (s *Suspension) IsActive(now int64) bool {
+	return s.LiftedAt == 0 && (s.ExpiresAt == 0 || now < s.ExpiresAt)
+}
@@ -0,0 +1,24 @@
+package models
+
+// Team takes part in a team contest as one participant. Members join with the team's invite code
+// until the leader registers the team.
+type Team struct {
+	ID           string       `json:"id"` // UUID as string
+	ContestID    string       `json:"contest_id"`
+	Name         string       `json:"name"`
+	InviteCode   string       `json:"invite_code"`
+	LeaderID     string       `json:"leader_id"`
+	CreatedAt    int64        `json:"created_at"`    // Unix timestamp
+	RegisteredAt int64        `json:"registered_at"` // Unix timestamp, 0 until the team is registered
+	Members      []TeamMember `json:"members"`
+}
+
+type TeamMember struct {
+	UserID   string `json:"user_id"`
+	JoinedAt int64  `json:"joined_at"` // Unix timestamp
+}
+
+// IsRegistered reports whether the leader registered the team, after which members can no longer change
+func (t *Team) IsRegistered() bool {
+	return t.RegisteredAt > 0
+}
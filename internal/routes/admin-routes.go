@@ -3,6 +3,7 @@ package routes
 import (
 	"app/internal/controllers"
 	"app/internal/middleware"
+	"app/internal/models"
 	"app/internal/models/dto"
 	"app/internal/services"
 
@@ -15,31 +16,164 @@ import (
 func AddAdminRoutes(
 	e *echo.Echo,
 	contestController *controllers.ContestController,
+	announcementController *controllers.AnnouncementController,
+	clarificationController *controllers.ClarificationController,
+	driveController *controllers.DriveController,
+	interviewController *controllers.InterviewController,
+	roleController *controllers.RoleController,
+	userController *controllers.UserController,
+	suspensionController *controllers.SuspensionController,
+	auditController *controllers.AuditController,
 	authClient *auth.Client,
 	userService *services.UserService,
 	adminService *services.AdminService,
+	auditService *services.AuditService,
 ) {
 	adminGroup := e.Group("/admin")
 	adminGroup.Use(middleware.RequireFirebaseAuth(authClient))
-	adminGroup.Use(middleware.RequireAdminRole(userService, adminService))
+	adminGroup.Use(middleware.AdminAuth(userService, adminService))
+	adminGroup.Use(middleware.Audit(auditService))
 
 	// Check Is Admin
 	adminGroup.GET("/", func(c echo.Context) error {
 		return c.NoContent(http.StatusOK)
 	})
 
+	//Roles, scoped grants only apply to the routes of their contest
+	adminGroup.GET("/roles/me", roleController.HandleGetMyRoles)
+	adminGroup.GET("/roles", roleController.HandleListRoles, middleware.RequirePermission(models.PermRolesManage))
+	adminGroup.POST("/roles", roleController.HandleGrantRole, middleware.RequirePermission(models.PermRolesManage), middleware.ValidateRequest(new(dto.GrantRoleRequest)))
+	adminGroup.DELETE("/roles/:grantid", roleController.HandleRevokeRole, middleware.RequirePermission(models.PermRolesManage))
+
+	//Audit Log, every successful mutation under /admin
+	adminGroup.GET("/audit", auditController.HandleListAuditLog, middleware.RequirePermission(models.PermAuditRead), middleware.ValidateRequest(new(dto.ListAuditLogRequest)))
+
+	//User Management, bans also disable the Firebase account
+	adminGroup.GET("/users", userController.HandleListUsers, middleware.RequirePermission(models.PermRead), middleware.ValidateRequest(new(dto.AdminListUsersRequest)))
+	adminGroup.GET("/users/:userid", userController.HandleGetUser, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/users/:userid", userController.HandleUpdateUser, middleware.RequirePermission(models.PermUsersManage), middleware.ValidateRequest(new(dto.AdminUpdateUserRequest)))
+	adminGroup.POST("/users/:userid/ban", userController.HandleBanUser, middleware.RequirePermission(models.PermUsersManage), middleware.ValidateRequest(new(dto.BanUserRequest)))
+	adminGroup.DELETE("/users/:userid/ban", userController.HandleUnbanUser, middleware.RequirePermission(models.PermUsersManage))
+
+	//Suspensions, blocking registration and submissions everywhere or in one contest until they expire or are lifted
+	adminGroup.GET("/suspensions", suspensionController.HandleListSuspensions, middleware.RequirePermission(models.PermRead), middleware.ValidateRequest(new(dto.ListSuspensionsRequest)))
+	adminGroup.POST("/users/:userid/suspensions", suspensionController.HandleSuspendUser, middleware.RequirePermission(models.PermUsersManage), middleware.ValidateRequest(new(dto.CreateSuspensionRequest)))
+	adminGroup.POST("/suspensions/:suspensionid/lift", suspensionController.HandleLiftSuspension, middleware.RequirePermission(models.PermUsersManage), middleware.ValidateRequest(new(dto.LiftSuspensionRequest)))
+
 	//Contest Management
-	adminGroup.GET("/contests/list", contestController.ListContests)
-	adminGroup.GET("/contest/:id", contestController.GetContest)
-	adminGroup.POST("/contest", contestController.HandleCreateContest, middleware.ValidateRequest(new(dto.UpsertContestRequest)))
-	adminGroup.PUT("/contest/:id", contestController.HandleUpdateContest, middleware.ValidateRequest(new(dto.UpsertContestRequest)))
-	adminGroup.DELETE("/contest/:id", contestController.HandleDeleteContest)
+	adminGroup.GET("/contests/list", contestController.HandleListContests, middleware.RequirePermission(models.PermRead), middleware.ValidateRequest(new(dto.AdminListContestsRequest)))
+	adminGroup.GET("/contest/:id", contestController.HandleGetContest, middleware.RequireContestPermission(models.PermRead, "id"))
+	adminGroup.POST("/contest", contestController.HandleCreateContest, middleware.RequirePermission(models.PermContestsWrite), middleware.ValidateRequest(new(dto.UpsertContestRequest)))
+	adminGroup.PUT("/contest/:id", contestController.HandleUpdateContest, middleware.RequireContestPermission(models.PermContestsWrite, "id"), middleware.ValidateRequest(new(dto.UpsertContestRequest)))
+	adminGroup.DELETE("/contest/:id", contestController.HandleDeleteContest, middleware.RequireContestPermission(models.PermContestsDelete, "id"))
+	adminGroup.PUT("/contest/:id/status", contestController.HandleUpdateContestStatus, middleware.RequireContestPermission(models.PermContestsWrite, "id"), middleware.ValidateRequest(new(dto.UpdateContestStatusRequest)))
+	adminGroup.POST("/contest/:id/clone", contestController.HandleCloneContest, middleware.RequirePermission(models.PermContestsWrite), middleware.ValidateRequest(new(dto.CloneContestRequest)))
+
+	//Contest Import/Export
+	adminGroup.GET("/contest/:id/export", contestController.HandleExportContest, middleware.RequireContestPermission(models.PermRead, "id"))
+	adminGroup.POST("/contests/import", contestController.HandleImportContest, middleware.RequirePermission(models.PermContestsWrite))
+
+	//Contest Templates
+	adminGroup.POST("/contest/:id/template", contestController.HandleSaveTemplate, middleware.RequireContestPermission(models.PermContestsWrite, "id"), middleware.ValidateRequest(new(dto.SaveTemplateRequest)))
+	adminGroup.GET("/templates", contestController.HandleListTemplates, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/templates/:id/instantiate", contestController.HandleInstantiateTemplate, middleware.RequirePermission(models.PermContestsWrite), middleware.ValidateRequest(new(dto.InstantiateTemplateRequest)))
+
+	//Recruitment Drives, rounds are contests run in order and users shortlisted in a round may take the next
+	adminGroup.GET("/drives", driveController.HandleListDrives, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/drives", driveController.HandleCreateDrive, middleware.RequirePermission(models.PermDrivesManage), middleware.ValidateRequest(new(dto.UpsertDriveRequest)))
+	adminGroup.GET("/drives/:driveid", driveController.HandleGetDrive, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/drives/:driveid", driveController.HandleUpdateDrive, middleware.RequirePermission(models.PermDrivesManage), middleware.ValidateRequest(new(dto.UpsertDriveRequest)))
+	adminGroup.DELETE("/drives/:driveid", driveController.HandleDeleteDrive, middleware.RequirePermission(models.PermDrivesManage))
+	adminGroup.PUT("/drives/:driveid/rounds", driveController.HandleSetDriveRounds, middleware.RequirePermission(models.PermDrivesManage), middleware.ValidateRequest(new(dto.SetDriveRoundsRequest)))
+	adminGroup.GET("/drives/:driveid/funnel", driveController.HandleGetDriveFunnel, middleware.RequirePermission(models.PermRead))
+
+	//Interviews, for candidates shortlisted in the final round of a drive
+	adminGroup.GET("/drives/:driveid/interviews", interviewController.HandleListInterviews, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/drives/:driveid/interviews", interviewController.HandleCreateInterview, middleware.RequirePermission(models.PermDrivesManage), middleware.ValidateRequest(new(dto.UpsertInterviewRequest)))
+	adminGroup.PUT("/drives/:driveid/interviews/:interviewid", interviewController.HandleUpdateInterview, middleware.RequirePermission(models.PermDrivesManage), middleware.ValidateRequest(new(dto.UpsertInterviewRequest)))
+	adminGroup.DELETE("/drives/:driveid/interviews/:interviewid", interviewController.HandleDeleteInterview, middleware.RequirePermission(models.PermDrivesManage))
+	adminGroup.GET("/drives/:driveid/interviews/:interviewid/feedback", interviewController.HandleListFeedback, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/drives/:driveid/interviews/:interviewid/feedback", interviewController.HandleSubmitFeedback, middleware.RequirePermission(models.PermInterviewsFeedback), middleware.ValidateRequest(new(dto.SubmitFeedbackRequest)))
+	adminGroup.GET("/drives/:driveid/decisions", interviewController.HandleListDecisions, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/drives/:driveid/decisions/:userid", interviewController.HandleSetDecision, middleware.RequirePermission(models.PermDrivesManage), middleware.ValidateRequest(new(dto.SetDecisionRequest)))
 
 	//Problem Management
-	adminGroup.POST("/:contestid/problem", contestController.HandleCreateProblem)
-	adminGroup.PUT("/:contestid/:problemid", contestController.HandleUpdateProblem)
-	adminGroup.DELETE("/:contestid/:problemid", contestController.HandleDeleteProblem)
+	adminGroup.POST("/:contestid/problem", contestController.HandleCreateProblem, middleware.RequirePermission(models.PermProblemsWrite))
+	adminGroup.PUT("/:contestid/:problemid", contestController.HandleUpdateProblem, middleware.RequirePermission(models.PermProblemsWrite))
+	adminGroup.DELETE("/:contestid/:problemid", contestController.HandleDeleteProblem, middleware.RequirePermission(models.PermProblemsWrite))
+	adminGroup.PUT("/:contestid/problems/order", contestController.HandleReorderProblems, middleware.RequirePermission(models.PermProblemsWrite), middleware.ValidateRequest(new(dto.ReorderProblemsRequest)))
+	adminGroup.POST("/:contestid/problems/attach", contestController.HandleAttachProblem, middleware.RequirePermission(models.PermProblemsWrite), middleware.ValidateRequest(new(dto.AttachProblemRequest)))
+
+	//Problem Bank
+	adminGroup.GET("/bank", contestController.HandleListBankProblems, middleware.RequirePermission(models.PermRead), middleware.ValidateRequest(new(dto.ListBankProblemsRequest)))
+	adminGroup.POST("/bank", contestController.HandleCreateBankProblem, middleware.RequirePermission(models.PermProblemsWrite))
+	adminGroup.GET("/bank/:problemid", contestController.HandleGetBankProblem, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/bank/:problemid", contestController.HandleUpdateBankProblem, middleware.RequirePermission(models.PermProblemsWrite))
+	adminGroup.DELETE("/bank/:problemid", contestController.HandleDeleteBankProblem, middleware.RequirePermission(models.PermProblemsWrite))
+
+	//Contest Sections
+	adminGroup.GET("/:contestid/sections", contestController.HandleListSections, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/:contestid/sections", contestController.HandleCreateSection, middleware.RequirePermission(models.PermContestsWrite), middleware.ValidateRequest(new(dto.UpsertSectionRequest)))
+	adminGroup.PUT("/:contestid/sections/:sectionid", contestController.HandleUpdateSection, middleware.RequirePermission(models.PermContestsWrite), middleware.ValidateRequest(new(dto.UpsertSectionRequest)))
+	adminGroup.DELETE("/:contestid/sections/:sectionid", contestController.HandleDeleteSection, middleware.RequirePermission(models.PermContestsWrite))
+
+	//MCQ Option Management
+	adminGroup.GET("/:contestid/:problemid/options", contestController.HandleListOptions, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/:contestid/:problemid/options", contestController.HandleCreateOption, middleware.RequirePermission(models.PermProblemsWrite), middleware.ValidateRequest(new(dto.UpsertOptionRequest)))
+	adminGroup.PUT("/:contestid/:problemid/options/:optionid", contestController.HandleUpdateOption, middleware.RequirePermission(models.PermProblemsWrite), middleware.ValidateRequest(new(dto.UpsertOptionRequest)))
+	adminGroup.DELETE("/:contestid/:problemid/options/:optionid", contestController.HandleDeleteOption, middleware.RequirePermission(models.PermProblemsWrite))
+
+	//Problem Pools
+	adminGroup.GET("/:contestid/pools", contestController.HandleListPools, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/:contestid/pools/:tag", contestController.HandleUpsertPool, middleware.RequirePermission(models.PermProblemsWrite), middleware.ValidateRequest(new(dto.UpsertPoolRequest)))
+	adminGroup.DELETE("/:contestid/pools/:tag", contestController.HandleDeletePool, middleware.RequirePermission(models.PermProblemsWrite))
+	adminGroup.GET("/:contestid/problemset/:userid", contestController.HandleGetProblemSet, middleware.RequirePermission(models.PermRead))
+
+	//Problem Assets
+	adminGroup.GET("/:contestid/:problemid/assets", contestController.HandleListAssets, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/:contestid/:problemid/assets", contestController.HandleCreateAsset, middleware.RequirePermission(models.PermProblemsWrite))
+	adminGroup.DELETE("/:contestid/:problemid/assets/:assetid", contestController.HandleDeleteAsset, middleware.RequirePermission(models.PermProblemsWrite))
+
+	//Editorials and Reference Solutions
+	adminGroup.GET("/:contestid/:problemid/editorial", contestController.HandleGetEditorial, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/:contestid/:problemid/editorial", contestController.HandleUpsertEditorial, middleware.RequirePermission(models.PermProblemsWrite), middleware.ValidateRequest(new(dto.UpsertEditorialRequest)))
+	adminGroup.DELETE("/:contestid/:problemid/editorial", contestController.HandleDeleteEditorial, middleware.RequirePermission(models.PermProblemsWrite))
+	adminGroup.GET("/:contestid/:problemid/solutions", contestController.HandleListSolutions, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/:contestid/:problemid/solutions", contestController.HandleCreateSolution, middleware.RequirePermission(models.PermProblemsWrite), middleware.ValidateRequest(new(dto.CreateSolutionRequest)))
+	adminGroup.DELETE("/:contestid/:problemid/solutions/:solutionid", contestController.HandleDeleteSolution, middleware.RequirePermission(models.PermProblemsWrite))
+
+	//Announcements
+	adminGroup.GET("/:contestid/announcements", announcementController.HandleListAnnouncements, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/:contestid/announcements", announcementController.HandleCreateAnnouncement, middleware.RequirePermission(models.PermContestsWrite), middleware.ValidateRequest(new(dto.UpsertAnnouncementRequest)))
+	adminGroup.PUT("/:contestid/announcements/:announcementid", announcementController.HandleUpdateAnnouncement, middleware.RequirePermission(models.PermContestsWrite), middleware.ValidateRequest(new(dto.UpsertAnnouncementRequest)))
+	adminGroup.DELETE("/:contestid/announcements/:announcementid", announcementController.HandleDeleteAnnouncement, middleware.RequirePermission(models.PermContestsWrite))
+
+	//Clarifications
+	adminGroup.GET("/:contestid/clarifications", clarificationController.HandleListClarifications, middleware.RequirePermission(models.PermRead), middleware.ValidateRequest(new(dto.ListClarificationsRequest)))
+	adminGroup.PUT("/:contestid/clarifications/:clarificationid", clarificationController.HandleAnswerClarification, middleware.RequirePermission(models.PermParticipantsManage), middleware.ValidateRequest(new(dto.AnswerClarificationRequest)))
+
+	//Test Case Management
+	adminGroup.GET("/:contestid/:problemid/testcases", contestController.HandleListTestCases, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/:contestid/:problemid/testcases", contestController.HandleCreateTestCase, middleware.RequirePermission(models.PermProblemsWrite), middleware.ValidateRequest(new(dto.CreateTestCaseRequest)))
+	adminGroup.DELETE("/:contestid/:problemid/testcases/:testcaseid", contestController.HandleDeleteTestCase, middleware.RequirePermission(models.PermProblemsWrite))
 
 	//Leaderboard/User Management
-	adminGroup.PUT("/:contestid/leaderboard/:userid", contestController.HandleUpdateLeaderboardUser)
+	adminGroup.GET("/:contestid/waitlist", contestController.HandleListWaitlist, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/:contestid/leaderboard/:userid", contestController.HandleUpdateLeaderboardUser, middleware.RequirePermission(models.PermParticipantsManage))
+	adminGroup.GET("/:contestid/leaderboard/:userid/disqualifications", contestController.HandleListDisqualifications, middleware.RequirePermission(models.PermRead))
+
+	//Access List, overrides the eligibility rules per user and deny always wins
+	adminGroup.GET("/:contestid/access", contestController.HandleListAccess, middleware.RequirePermission(models.PermRead))
+	adminGroup.PUT("/:contestid/access/:userid", contestController.HandleSetAccess, middleware.RequirePermission(models.PermParticipantsManage), middleware.ValidateRequest(new(dto.SetAccessRequest)))
+	adminGroup.DELETE("/:contestid/access/:userid", contestController.HandleDeleteAccess, middleware.RequirePermission(models.PermParticipantsManage))
+
+	//Invites, redeeming a code or being shortlisted puts users on the allow list
+	adminGroup.GET("/:contestid/invites", contestController.HandleListInviteCodes, middleware.RequirePermission(models.PermRead))
+	adminGroup.POST("/:contestid/invites", contestController.HandleCreateInviteCode, middleware.RequirePermission(models.PermParticipantsManage), middleware.ValidateRequest(new(dto.CreateInviteCodeRequest)))
+	adminGroup.DELETE("/:contestid/invites/:code", contestController.HandleDeleteInviteCode, middleware.RequirePermission(models.PermParticipantsManage))
+	adminGroup.POST("/:contestid/invites/shortlisted", contestController.HandleInviteShortlisted, middleware.RequirePermission(models.PermParticipantsManage), middleware.ValidateRequest(new(dto.InviteShortlistedRequest)))
+
+	//Disqualification Appeals
+	adminGroup.GET("/:contestid/appeals", contestController.HandleListAppeals, middleware.RequirePermission(models.PermRead), middleware.ValidateRequest(new(dto.ListAppealsRequest)))
+	adminGroup.PUT("/:contestid/appeals/:appealid", contestController.HandleResolveAppeal, middleware.RequirePermission(models.PermParticipantsManage), middleware.ValidateRequest(new(dto.ResolveAppealRequest)))
 }
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"app/internal/controllers"
+	"app/internal/middleware"
+	"app/internal/services"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/labstack/echo/v4"
+)
+
+func AddAnnouncementRoutes(
+	e *echo.Echo,
+	authClient *auth.Client,
+	announcementController *controllers.AnnouncementController,
+	announcementService *services.AnnouncementService,
+) {
+	// Streams never finish on their own, end them as soon as the server starts shutting down
+	// so they don't hold it open until the shutdown times out
+	e.Server.RegisterOnShutdown(announcementService.Close)
+
+	// List the announcements of a contest the authenticated user is registered in, newest first
+	// Announcements about problems the user was not given are left out
+	e.GET("/contests/:id/announcements",
+		announcementController.ListAnnouncements,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
+	// Stream announcement changes of a contest as server-sent events
+	// Events are named created, updated or deleted and carry the announcement as data,
+	// deleted events only carry its id, contest_id and problem_id
+	// Announcements about a problem are only sent to users who were given that problem
+	// The stream closes if the client falls behind, clients should then reload the list and reconnect
+	e.GET("/contests/:id/announcements/stream",
+		announcementController.StreamAnnouncements,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"app/internal/controllers"
+	"app/internal/middleware"
+	"app/internal/models/dto"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/labstack/echo/v4"
+)
+
+func AddClarificationRoutes(
+	e *echo.Echo,
+	authClient *auth.Client,
+	clarificationController *controllers.ClarificationController,
+) {
+	// Ask the admins a question about a contest or one of its problems
+	// Only registered users while the contest is running, a few questions per user every 10 minutes
+	e.POST("/contests/:id/clarifications",
+		clarificationController.CreateClarification,
+		middleware.RequireFirebaseAuth(authClient),
+		middleware.ValidateRequest(new(dto.CreateClarificationRequest)),
+	)
+
+	// List the authenticated user's clarifications in a contest, with the ones answered for everyone
+	e.GET("/contests/:id/clarifications",
+		clarificationController.ListClarifications,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"app/internal/controllers"
 	"app/internal/middleware"
 	"app/internal/models/dto"
+	"app/internal/services"
 
 	"firebase.google.com/go/v4/auth"
 	"github.com/labstack/echo/v4"
@@ -13,6 +14,7 @@ func AddContestRoutes(
 	e *echo.Echo,
 	authClient *auth.Client,
 	contestController *controllers.ContestController,
+	suspensionService *services.SuspensionService,
 ) {
 	// List all contests
 	e.GET("/contests/list",
@@ -20,6 +22,12 @@ func AddContestRoutes(
 		middleware.OptionalFirebaseAuth(authClient),
 	)
 
+	// List archived contests
+	e.GET("/contests/past",
+		contestController.ListPastContests,
+		middleware.OptionalFirebaseAuth(authClient),
+	)
+
 	// Get details of a specific contest
 	// If the user is authenticated, return user-specific details
 	// If not, return public details
@@ -36,22 +44,78 @@ func AddContestRoutes(
 
 	// Register/Unregister the authenticated user for a specific contest
 	// Use a request body with action=register or action=unregister
+	// Registering for a full contest joins its waitlist and responds 202 with the waitlist position,
+	// unregistering gives the seat to the first user on the waitlist.
+	// An invite_code given when registering is redeemed first, which is how users join private contests
+	// Users suspended globally or from the contest are rejected
 	e.POST("/contests/:id/registration",
 		contestController.ModifyRegistration,
 		middleware.RequireFirebaseAuth(authClient),
+		middleware.RequireNotSuspended(suspensionService),
 		middleware.ValidateRequest(new(dto.ModifyRegistrationRequest)),
 	)
 
+	// Start the authenticated user's personal attempt window in a timed contest
+	// The attempt lasts for the contest duration but never beyond the contest end time
+	e.POST("/contests/:id/start",
+		contestController.StartAttempt,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
+	// Start a virtual participation of the authenticated user in an ended contest they did not take part in
+	// It lasts the contest's original duration, submissions made during it are graded as practice
+	e.POST("/contests/:id/virtual",
+		contestController.StartVirtual,
+		middleware.RequireFirebaseAuth(authClient),
+		middleware.RequireNotSuspended(suspensionService),
+	)
+
+	// Get the leaderboard of the authenticated user's virtual participation
+	// Official participants are ranked by their score at the same time into the contest
+	e.GET("/contests/:id/virtual/leaderboard",
+		contestController.GetVirtualLeaderboard,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
 	// Get the problems of a specific contest for the authenticated user
 	// Do not return the problem statements themselves
+	// Contests with shuffling or pools return the user's own problem set and order
 	e.GET("/contests/:id/problems",
 		contestController.GetContestProblemsList,
 		middleware.RequireFirebaseAuth(authClient),
 	)
 
+	// Get the sections of a specific contest with their instructions, for the authenticated user
+	// Problems refer to their section by section_id
+	e.GET("/contests/:id/sections",
+		contestController.GetContestSections,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
 	// Get the problem statement of a specific problem in a contest for the authenticated user
 	e.GET("/contests/:id/problems/:problem_id",
 		contestController.GetContestProblem,
 		middleware.RequireFirebaseAuth(authClient),
 	)
+
+	// Download an image or attachment of a problem statement through the API
+	// Statements reference assets as asset://<name>, which the problem endpoint rewrites to short-lived presigned S3 URLs
+	e.GET("/contests/:id/problems/:problem_id/assets/:name",
+		contestController.GetProblemAsset,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
+	// Get the editorial and reference solutions of a problem once the contest has ended
+	e.GET("/contests/:id/problems/:problem_id/editorial",
+		contestController.GetProblemEditorial,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
+	// Appeal the authenticated user's current disqualification in a specific contest
+	// Only one appeal can be pending at a time
+	e.POST("/contests/:id/appeal",
+		contestController.CreateAppeal,
+		middleware.RequireFirebaseAuth(authClient),
+		middleware.ValidateRequest(new(dto.CreateAppealRequest)),
+	)
 }
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"app/internal/controllers"
+	"app/internal/middleware"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/labstack/echo/v4"
+)
+
+func AddDriveRoutes(
+	e *echo.Echo,
+	authClient *auth.Client,
+	driveController *controllers.DriveController,
+	interviewController *controllers.InterviewController,
+) {
+	// Get a recruitment drive with the authenticated user's progress through its rounds,
+	// a round unlocks once the user is shortlisted in the one before
+	e.GET("/drives/:id",
+		driveController.GetDriveProgress,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
+	// List the authenticated user's scheduled interview slots in a drive
+	e.GET("/drives/:id/interviews",
+		interviewController.ListInterviews,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"app/internal/controllers"
 	"app/internal/middleware"
+	"app/internal/services"
 	"firebase.google.com/go/v4/auth"
 	"github.com/labstack/echo/v4"
 	"app/internal/models/dto"
@@ -12,6 +13,7 @@ func AddSubmissionRoutes(
 	e *echo.Echo,
 	authClient *auth.Client,
 	submissionController *controllers.SubmissionController,
+	suspensionService *services.SuspensionService,
 ) {
 	// // Get the status of a specific submission
 	// // The authenticated user can only get the status of their own submissions
@@ -41,9 +43,11 @@ func AddSubmissionRoutes(
 	// // The request body should contain the contest ID, problem ID, language, and code
 	// // For MCQ type questions, the request body should contain the selected option(s)
 	// // The response should contain the submission ID
+	// // Users suspended globally or from the contest are rejected
 	e.POST("/submission/submit",
 		submissionController.SubmitSolution,
 		middleware.RequireFirebaseAuth(authClient),
 		middleware.ValidateRequest(new(dto.SubmitSubmissionRequest)),
+		middleware.RequireNotSuspended(suspensionService),
 	)
 }
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"app/internal/controllers"
+	"app/internal/middleware"
+	"app/internal/models/dto"
+	"app/internal/services"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/labstack/echo/v4"
+)
+
+func AddTeamRoutes(
+	e *echo.Echo,
+	authClient *auth.Client,
+	teamController *controllers.TeamController,
+	suspensionService *services.SuspensionService,
+) {
+	// Teams of a team contest only change while its registration is open.
+	// A user is in at most one team per contest. Suspended users cannot create, join or register teams.
+
+	// Create a team led by the authenticated user, the response holds the invite code for other members
+	e.POST("/contests/:id/teams",
+		teamController.CreateTeam,
+		middleware.RequireFirebaseAuth(authClient),
+		middleware.RequireNotSuspended(suspensionService),
+		middleware.ValidateRequest(new(dto.CreateTeamRequest)),
+	)
+
+	// Join a team with its invite code, up to the contest's max_team_size members
+	e.POST("/contests/:id/teams/join",
+		teamController.JoinTeam,
+		middleware.RequireFirebaseAuth(authClient),
+		middleware.RequireNotSuspended(suspensionService),
+		middleware.ValidateRequest(new(dto.JoinTeamRequest)),
+	)
+
+	// Get the team leaderboard, refreshed every minute. Hidden and disqualified teams are left out.
+	// Paginate, page=<page> and 20 entries per page
+	e.GET("/contests/:id/teams/leaderboard", teamController.GetLeaderboard)
+
+	// Get the authenticated user's team with its members
+	e.GET("/contests/:id/teams/me",
+		teamController.GetTeam,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
+	// Leave the team before it is registered, the leader leaving disbands it
+	e.DELETE("/contests/:id/teams/me",
+		teamController.LeaveTeam,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+
+	// Register or unregister the team, for the leader only. Every member must be eligible
+	// and the team must have between min_team_size and max_team_size members.
+	e.POST("/contests/:id/teams/me/registration",
+		teamController.RegisterTeam,
+		middleware.RequireFirebaseAuth(authClient),
+		middleware.RequireNotSuspended(suspensionService),
+	)
+	e.DELETE("/contests/:id/teams/me/registration",
+		teamController.UnregisterTeam,
+		middleware.RequireFirebaseAuth(authClient),
+	)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -76,3 +77,46 @@ func (s *S3) GetObject(context context.Context, key string) (string, error) {
 
 	return string(body), nil
 }
+
+// PresignGetObject returns a URL that anyone can download the object from until it expires,
+// served with the given content type
+func (s *S3) PresignGetObject(context context.Context, key string, contentType string, expires time.Duration) (string, error) {
+	req, err := s3.NewPresignClient(s.client).PresignGetObject(context, &s3.GetObjectInput{
+		Bucket:              aws.String(s.Bucket),
+		Key:                 aws.String(key),
+		ResponseContentType: aws.String(contentType),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		log.Errorf("s3: failed to presign object: %v", err)
+		return "", err
+	}
+	return req.URL, nil
+}
+
+func (s *S3) DeleteObject(context context.Context, key string) error {
+	_, err := s.client.DeleteObject(context, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		log.Errorf("s3: failed to delete object: %v", err)
+	}
+	return err
+}
+
+func (s *S3) CopyObject(context context.Context, sourceKey string, destinationKey string) error {
+	_, err := s.client.CopyObject(context, &s3.CopyObjectInput{
+		Bucket:     aws.String(s.Bucket),
+		CopySource: aws.String(s.Bucket + "/" + sourceKey),
+		Key:        aws.String(destinationKey),
+	})
+	if err != nil {
+		var notFound *types.NoSuchKey
+		if errors.As(err, &notFound) {
+			log.Errorf("s3: key %s not found: %v", sourceKey, err)
+			return common.KeyNotFoundError
+		}
+		log.Errorf("s3: failed to copy object: %v", err)
+	}
+	return err
+}
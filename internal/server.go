@@ -16,6 +16,7 @@ func NewEchoServer(
 ) *echo.Echo {
 	e := echo.New()
 	e.Use(mdw.Recover())
+	e.Use(mdw.RequestID())
 	e.Use(mdw.Logger())
 	e.Use(mdw.CORSWithConfig(mdw.CORSConfig{
 		AllowOrigins: []string{
@@ -1,8 +1,14 @@
 package services
 
 import (
+	"app/internal/common"
+	"app/internal/models"
+	"app/internal/models/dto"
 	"app/internal/stores"
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type AdminService struct {
@@ -13,7 +19,48 @@ func NewAdminService(stores *stores.Storage) *AdminService {
 	return &AdminService{stores: stores}
 }
 
-// IsAdmin checks if a user is an admin.
-func (s *AdminService) IsAdmin(ctx context.Context, userID string) (bool, error) {
-	return s.stores.Admins.IsAdmin(ctx, userID)
+// GetGrants returns the roles held by a user. Users without any are not admins.
+func (s *AdminService) GetGrants(ctx context.Context, userID string) (models.Grants, error) {
+	return s.stores.Admins.ListGrants(ctx, userID)
+}
+
+// ListGrants lists the roles of every admin
+func (s *AdminService) ListGrants(ctx context.Context) (models.Grants, error) {
+	return s.stores.Admins.ListGrants(ctx, "")
+}
+
+// GrantRole gives a user a role, scoped to a contest if the request names one
+func (s *AdminService) GrantRole(ctx context.Context, adminID string, req *dto.GrantRoleRequest) (*models.RoleGrant, error) {
+	if req.Role == models.RoleSuperAdmin && req.ContestID != "" {
+		return nil, common.SuperAdminScopedError
+	}
+
+	if _, err := s.stores.Users.GetUserProfile(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	if req.ContestID != "" {
+		if _, err := s.stores.Contests.GetContest(ctx, req.ContestID); err != nil {
+			return nil, err
+		}
+	}
+
+	grant := &models.RoleGrant{
+		ID:        uuid.NewString(),
+		UserID:    req.UserID,
+		Role:      req.Role,
+		ContestID: req.ContestID,
+		GrantedBy: adminID,
+		GrantedAt: time.Now().Unix(),
+	}
+
+	if err := s.stores.Admins.GrantRole(ctx, grant); err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+func (s *AdminService) RevokeRole(ctx context.Context, grantID string) error {
+	return s.stores.Admins.RevokeRole(ctx, grantID)
 }
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"app/internal/common"
+	"app/internal/db"
+	"app/internal/models"
+	"app/internal/models/dto"
+	"app/internal/stores"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/fx"
+)
+
+// subscriberBuffer is how many events a live subscriber may fall behind before it is dropped
+const subscriberBuffer = 16
+
+// listenerPingInterval is how often the notification connection is checked
+const listenerPingInterval = 90 * time.Second
+
+type AnnouncementService struct {
+	stores *stores.Storage
+
+	// shutdown is cancelled when the app stops so open streams end instead of holding up the server
+	shutdown context.Context
+	stop     context.CancelFunc
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan models.AnnouncementEvent]struct{} // By contest ID
+}
+
+// NewAnnouncementService creates the announcement service. Events are published through Postgres
+// notifications so subscribers connected to any instance of the app receive them.
+func NewAnnouncementService(lc fx.Lifecycle, stores *stores.Storage) *AnnouncementService {
+	shutdown, stop := context.WithCancel(context.Background())
+	as := &AnnouncementService{
+		stores:      stores,
+		shutdown:    shutdown,
+		stop:        stop,
+		subscribers: make(map[string]map[chan models.AnnouncementEvent]struct{}),
+	}
+
+	listener := pq.NewListener(db.LoadDBConfig().GetConnectionString(), 10*time.Second, time.Minute,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Printf("announcement-service: listener event %d: %v", event, err)
+			}
+		},
+	)
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go as.listen(listener)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			as.Close()
+			return listener.Close()
+		},
+	})
+
+	return as
+}
+
+// Done is closed when the app shuts down, streams should end once it is
+func (as *AnnouncementService) Done() <-chan struct{} {
+	return as.shutdown.Done()
+}
+
+// Close ends the open streams, it is safe to call more than once
+func (as *AnnouncementService) Close() {
+	as.stop()
+}
+
+// listen delivers the announcement events published by any instance to the local subscribers
+func (as *AnnouncementService) listen(listener *pq.Listener) {
+	if err := listener.Listen(stores.AnnouncementEventsChannel); err != nil {
+		log.Printf("announcement-service: listen failed: %v", err)
+		return
+	}
+
+	ping := time.NewTicker(listenerPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-as.shutdown.Done():
+			return
+		case n, ok := <-listener.Notify:
+			if !ok {
+				return
+			}
+			if n == nil {
+				// The connection was re-established, events sent in between are lost
+				continue
+			}
+
+			event, err := stores.ParseAnnouncementEvent(n.Extra)
+			if err != nil {
+				log.Printf("announcement-service: %v", err)
+				continue
+			}
+
+			if event.Type != models.AnnouncementDeleted {
+				announcement, err := as.stores.Announcements.GetAnnouncement(as.shutdown, event.Announcement.ContestID, event.Announcement.ID)
+				if err != nil {
+					if err != common.AnnouncementNotFoundError {
+						log.Printf("announcement-service: load announcement %s: %v", event.Announcement.ID, err)
+					}
+					continue
+				}
+				event.Announcement = *announcement
+			}
+
+			as.dispatch(event)
+		case <-ping.C:
+			// Notices a dropped connection the listener did not, it reconnects on its own
+			go listener.Ping()
+		}
+	}
+}
+
+func (as *AnnouncementService) ListAnnouncements(ctx context.Context, contestID string) ([]models.Announcement, error) {
+	return as.stores.Announcements.ListAnnouncements(ctx, contestID)
+}
+
+func (as *AnnouncementService) CreateAnnouncement(ctx context.Context, contestID string, adminID string, req *dto.UpsertAnnouncementRequest) (*models.Announcement, error) {
+	if _, err := as.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	if err := as.validateProblem(ctx, contestID, req.ProblemID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	announcement := &models.Announcement{
+		ID:        uuid.NewString(),
+		ContestID: contestID,
+		ProblemID: req.ProblemID,
+		Title:     req.Title,
+		Body:      req.Body,
+		CreatedBy: adminID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := as.stores.Announcements.CreateAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+
+	as.publish(ctx, models.AnnouncementEvent{Type: models.AnnouncementCreated, Announcement: *announcement})
+	return announcement, nil
+}
+
+func (as *AnnouncementService) UpdateAnnouncement(ctx context.Context, contestID string, announcementID string, req *dto.UpsertAnnouncementRequest) (*models.Announcement, error) {
+	announcement, err := as.stores.Announcements.GetAnnouncement(ctx, contestID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := as.validateProblem(ctx, contestID, req.ProblemID); err != nil {
+		return nil, err
+	}
+
+	announcement.ProblemID = req.ProblemID
+	announcement.Title = req.Title
+	announcement.Body = req.Body
+	announcement.UpdatedAt = time.Now().Unix()
+
+	if err := as.stores.Announcements.UpdateAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+
+	as.publish(ctx, models.AnnouncementEvent{Type: models.AnnouncementUpdated, Announcement: *announcement})
+	return announcement, nil
+}
+
+func (as *AnnouncementService) DeleteAnnouncement(ctx context.Context, contestID string, announcementID string) error {
+	announcement, err := as.stores.Announcements.GetAnnouncement(ctx, contestID, announcementID)
+	if err != nil {
+		return err
+	}
+
+	if err := as.stores.Announcements.DeleteAnnouncement(ctx, contestID, announcementID); err != nil {
+		return err
+	}
+
+	as.publish(ctx, models.AnnouncementEvent{Type: models.AnnouncementDeleted, Announcement: *announcement})
+	return nil
+}
+
+// validateProblem checks that an announcement scoped to a problem refers to a problem of the contest
+func (as *AnnouncementService) validateProblem(ctx context.Context, contestID string, problemID string) error {
+	if problemID == "" {
+		return nil
+	}
+
+	if _, err := as.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		if err == common.ContestNotFoundError {
+			return common.ProblemNotFoundError
+		}
+		return err
+	}
+	return nil
+}
+
+// Subscribe delivers the announcement events of a contest until the returned function is called.
+// The channel is closed if the subscriber falls too far behind, after which it should reload
+// the announcements and subscribe again.
+func (as *AnnouncementService) Subscribe(contestID string) (<-chan models.AnnouncementEvent, func()) {
+	ch := make(chan models.AnnouncementEvent, subscriberBuffer)
+
+	as.mu.Lock()
+	if as.subscribers[contestID] == nil {
+		as.subscribers[contestID] = make(map[chan models.AnnouncementEvent]struct{})
+	}
+	as.subscribers[contestID][ch] = struct{}{}
+	as.mu.Unlock()
+
+	return ch, func() {
+		as.mu.Lock()
+		defer as.mu.Unlock()
+		as.unsubscribe(contestID, ch)
+	}
+}
+
+// unsubscribe removes and closes a subscriber, as.mu must be held
+func (as *AnnouncementService) unsubscribe(contestID string, ch chan models.AnnouncementEvent) {
+	if _, ok := as.subscribers[contestID][ch]; !ok {
+		return
+	}
+
+	delete(as.subscribers[contestID], ch)
+	if len(as.subscribers[contestID]) == 0 {
+		delete(as.subscribers, contestID)
+	}
+	close(ch)
+}
+
+// publish announces an event to every instance, the change is already saved so failures are only logged
+func (as *AnnouncementService) publish(ctx context.Context, event models.AnnouncementEvent) {
+	if err := as.stores.Announcements.PublishAnnouncementEvent(ctx, event); err != nil {
+		log.Printf("announcement-service: publish %s event failed: %v", event.Type, err)
+	}
+}
+
+// dispatch sends an event to the subscribers of its contest connected to this instance
+func (as *AnnouncementService) dispatch(event models.AnnouncementEvent) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	contestID := event.Announcement.ContestID
+	for ch := range as.subscribers[contestID] {
+		select {
+		case ch <- event:
+		default:
+			as.unsubscribe(contestID, ch)
+		}
+	}
+}
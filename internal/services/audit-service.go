@@ -0,0 +1,119 @@
+package services
+
+import (
+	"app/internal/models"
+	"app/internal/models/dto"
+	"app/internal/stores"
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// auditPurgeInterval is how often entries past the retention period are purged
+const auditPurgeInterval = 24 * time.Hour
+
+type AuditService struct {
+	stores *stores.Storage
+	// retention is how long entries are kept, zero keeps them forever
+	retention time.Duration
+}
+
+// NewAuditService creates the audit service and purges entries older than AUDIT_RETENTION_DAYS
+// (default 365, 0 to keep them forever) once a day while the app runs
+func NewAuditService(lc fx.Lifecycle, stores *stores.Storage) *AuditService {
+	days, err := strconv.Atoi(os.Getenv("AUDIT_RETENTION_DAYS"))
+	if err != nil || days < 0 {
+		days = 365
+	}
+
+	as := &AuditService{
+		stores:    stores,
+		retention: time.Duration(days) * 24 * time.Hour,
+	}
+
+	if as.retention == 0 {
+		return as
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go as.purgeLoop(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+
+	return as
+}
+
+func (as *AuditService) purgeLoop(ctx context.Context) {
+	ticker := time.NewTicker(auditPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		cutoff := time.Now().Add(-as.retention).Unix()
+		if purged, err := as.stores.Audit.PurgeEntries(ctx, cutoff); err != nil {
+			log.Printf("audit-service: purge failed: %v", err)
+		} else if purged > 0 {
+			log.Printf("audit-service: purged %d entries", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Record appends an entry to the audit log, filling in the changes between before and after
+func (as *AuditService) Record(ctx context.Context, entry *models.AuditEntry) error {
+	entry.Changes = diffJSON(entry.Before, entry.After)
+	entry.CreatedAt = time.Now().Unix()
+	return as.stores.Audit.CreateEntry(ctx, entry)
+}
+
+func (as *AuditService) ListEntries(ctx context.Context, req *dto.ListAuditLogRequest) ([]models.AuditEntry, error) {
+	return as.stores.Audit.ListEntries(ctx, req)
+}
+
+// diffJSON returns the top-level fields of after whose value differs from before.
+// Nothing is returned unless both are JSON objects.
+func diffJSON(before json.RawMessage, after json.RawMessage) map[string]models.AuditChange {
+	var b, a map[string]json.RawMessage
+	if json.Unmarshal(before, &b) != nil || json.Unmarshal(after, &a) != nil || b == nil || a == nil {
+		return nil
+	}
+
+	changes := make(map[string]models.AuditChange)
+	for field, afterValue := range a {
+		beforeValue, ok := b[field]
+		if ok && jsonEqual(beforeValue, afterValue) {
+			continue
+		}
+		if !ok {
+			beforeValue = json.RawMessage("null")
+		}
+		changes[field] = models.AuditChange{Before: beforeValue, After: afterValue}
+	}
+
+	return changes
+}
+
+func jsonEqual(x json.RawMessage, y json.RawMessage) bool {
+	var vx, vy any
+	if json.Unmarshal(x, &vx) != nil || json.Unmarshal(y, &vy) != nil {
+		return false
+	}
+	return reflect.DeepEqual(vx, vy)
+}
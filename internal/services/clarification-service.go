@@ -0,0 +1,137 @@
+package services
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"app/internal/models/dto"
+	"app/internal/stores"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+)
+
+const (
+	// A user may ask at most clarificationLimit questions per clarificationWindow in a contest
+	clarificationLimit  = 3
+	clarificationWindow = 10 * time.Minute
+)
+
+type ClarificationService struct {
+	stores              *stores.Storage
+	contestService      *ContestService
+	announcementService *AnnouncementService
+}
+
+func NewClarificationService(stores *stores.Storage, contestService *ContestService, announcementService *AnnouncementService) *ClarificationService {
+	return &ClarificationService{
+		stores:              stores,
+		contestService:      contestService,
+		announcementService: announcementService,
+	}
+}
+
+// CreateClarification records a question from a registered user while the contest is running
+func (cs *ClarificationService) CreateClarification(ctx context.Context, contestID string, userID string, req *dto.CreateClarificationRequest) (*models.Clarification, error) {
+	contest, err := cs.contestService.GetContest(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if contest.IsRegistered == nil || !*contest.IsRegistered {
+		return nil, common.UserNotRegisteredError
+	}
+
+	if contest.GetRunningStatus() != models.ContestRunningOpen {
+		return nil, common.ContestNotRunningError
+	}
+
+	if req.ProblemID != "" {
+		if _, err := cs.stores.Problems.GetProblem(ctx, req.ProblemID, contestID); err != nil {
+			if err == common.ContestNotFoundError {
+				return nil, common.ProblemNotFoundError
+			}
+			return nil, err
+		}
+
+		if err := cs.contestService.CheckProblemInSet(ctx, contestID, userID, req.ProblemID); err != nil {
+			if err == common.ErrNotFound {
+				return nil, common.ProblemNotFoundError
+			}
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	clarification := &models.Clarification{
+		ID:        uuid.NewString(),
+		ContestID: contestID,
+		ProblemID: req.ProblemID,
+		UserID:    userID,
+		Question:  req.Question,
+		Status:    models.ClarificationPending,
+		CreatedAt: now.Unix(),
+	}
+
+	if err := cs.stores.Clarifications.CreateClarification(ctx, clarification, now.Add(-clarificationWindow).Unix(), clarificationLimit); err != nil {
+		return nil, err
+	}
+
+	return clarification, nil
+}
+
+// ListUserClarifications lists the user's own clarifications and the broadcast ones
+func (cs *ClarificationService) ListUserClarifications(ctx context.Context, contestID string, userID string) ([]models.Clarification, error) {
+	contest, err := cs.contestService.GetContest(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if contest.IsRegistered == nil || !*contest.IsRegistered {
+		return nil, common.UserNotRegisteredError
+	}
+
+	return cs.stores.Clarifications.ListUserClarifications(ctx, contestID, userID)
+}
+
+func (cs *ClarificationService) ListClarifications(ctx context.Context, contestID string, status models.ClarificationStatus, page int) ([]models.Clarification, error) {
+	return cs.stores.Clarifications.ListClarifications(ctx, contestID, status, page)
+}
+
+// AnswerClarification answers a clarification privately, or for everyone in the contest when
+// broadcast. The first broadcast of a clarification is also posted as an announcement.
+func (cs *ClarificationService) AnswerClarification(ctx context.Context, contestID string, clarificationID string, adminID string, req *dto.AnswerClarificationRequest) (*models.Clarification, error) {
+	clarification, err := cs.stores.Clarifications.GetClarification(ctx, contestID, clarificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	wasPublic := clarification.Public
+
+	clarification.Status = models.ClarificationAnswered
+	clarification.Answer = req.Answer
+	clarification.Public = req.Broadcast
+	clarification.AnsweredBy = adminID
+	clarification.AnsweredAt = time.Now().Unix()
+
+	if err := cs.stores.Clarifications.AnswerClarification(ctx, clarification); err != nil {
+		return nil, err
+	}
+
+	if clarification.Public && !wasPublic {
+		announcement := &dto.UpsertAnnouncementRequest{
+			Title:     "Clarification",
+			Body:      fmt.Sprintf("**Q:** %s\n\n**A:** %s", clarification.Question, clarification.Answer),
+			ProblemID: clarification.ProblemID,
+		}
+
+		// The answer is already saved and listed for everyone, a failed announcement only skips the notification
+		if _, err := cs.announcementService.CreateAnnouncement(ctx, contestID, adminID, announcement); err != nil {
+			log.Errorf("failed to announce clarification %s: %v", clarification.ID, err)
+		}
+	}
+
+	return clarification, nil
+}
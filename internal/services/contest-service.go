@@ -1,29 +1,54 @@
 package services
 
 import (
+	"app/internal/bundle"
 	"app/internal/common"
+	"app/internal/eligibility"
 	"app/internal/models"
 	"app/internal/models/dto"
+	"app/internal/s3"
 	"app/internal/stores"
 	"context"
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
 	"slices"
+	"strings"
+	"time"
 
 	"fmt"
 
 	"github.com/google/uuid"
+	gonanoid "github.com/matoous/go-nanoid/v2"
 
 	"github.com/labstack/gommon/log"
 )
 
+const contestIDAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// inviteCodeAlphabet leaves out characters that are easily confused when codes are typed in
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
 type ContestService struct {
 	stores *stores.Storage
+	s3     *s3.S3
 }
 
-func NewContestService(stores *stores.Storage) *ContestService {
-	return &ContestService{stores: stores}
+func NewContestService(stores *stores.Storage, s3 *s3.S3) *ContestService {
+	return &ContestService{stores: stores, s3: s3}
 }
 
+// CreateContest creates a new contest as a draft, which is only visible to admins until published
 func (cs *ContestService) CreateContest(ctx context.Context, contest *models.Contest) (*models.Contest, error) {
+	if err := validateEligibilityRules(contest); err != nil {
+		return nil, err
+	}
+
+	if err := validateTeamRules(contest); err != nil {
+		return nil, err
+	}
+
+	contest.Status = models.ContestDraft
 	if err := cs.stores.Contests.CreateContest(ctx, contest); err != nil {
 		return nil, err
 	}
@@ -31,12 +56,416 @@ func (cs *ContestService) CreateContest(ctx context.Context, contest *models.Con
 }
 
 func (cs *ContestService) UpdateContest(ctx context.Context, contest *models.Contest) (*models.Contest, error) {
+	if err := validateEligibilityRules(contest); err != nil {
+		return nil, err
+	}
+
+	if err := validateTeamRules(contest); err != nil {
+		return nil, err
+	}
+
 	if err := cs.stores.Contests.UpdateContest(ctx, contest); err != nil {
 		return nil, err
 	}
 	return contest, nil
 }
 
+// validateEligibilityRules checks the USN pattern compiles and drops blank departments
+func validateEligibilityRules(contest *models.Contest) error {
+	if contest.USNPattern != "" {
+		if _, err := eligibility.CompileUSNPattern(contest.USNPattern); err != nil {
+			return common.InvalidUSNPatternError
+		}
+	}
+
+	departments := make([]string, 0, len(contest.Departments))
+	for _, d := range contest.Departments {
+		if d = strings.TrimSpace(d); d != "" {
+			departments = append(departments, d)
+		}
+	}
+	contest.Departments = departments
+
+	return nil
+}
+
+// validateTeamRules checks the team sizes of team contests, teams have at least one member
+func validateTeamRules(contest *models.Contest) error {
+	if !contest.TeamMode {
+		contest.MinTeamSize, contest.MaxTeamSize = 0, 0
+		return nil
+	}
+
+	contest.MinTeamSize = max(contest.MinTeamSize, 1)
+	if contest.MaxTeamSize < contest.MinTeamSize {
+		return common.InvalidTeamSizeError
+	}
+	return nil
+}
+
+// UpdateContestStatus moves a contest through its draft/published/archived lifecycle.
+// A contest can only be published once it has problems and every code problem has test cases.
+func (cs *ContestService) UpdateContestStatus(ctx context.Context, contestID string, status models.ContestStatus) error {
+	contest, err := cs.stores.Contests.GetContest(ctx, contestID)
+	if err != nil {
+		return err
+	}
+
+	if !contest.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s to %s", common.InvalidStatusTransitionError, contest.Status, status)
+	}
+
+	if status == models.ContestPublished {
+		if contest.IsTemplate {
+			return common.TemplateNotPublishableError
+		}
+
+		count, err := cs.stores.Problems.CountProblems(ctx, contestID)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return common.ContestHasNoProblemsError
+		}
+
+		missing, err := cs.stores.Problems.ListProblemsWithoutTestCases(ctx, contestID)
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %s", common.ProblemsMissingTestCasesError, strings.Join(missing, ", "))
+		}
+	}
+
+	return cs.stores.Contests.UpdateContestStatus(ctx, contestID, contest.Status, status)
+}
+
+// CloneContest copies a contest with its problems, MCQ answers and test cases into a new draft,
+// shifting all of its times so that it starts at the requested start time
+func (cs *ContestService) CloneContest(ctx context.Context, sourceID string, req *dto.CloneContestRequest) (*models.Contest, error) {
+	source, err := cs.stores.Contests.GetContest(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	shift := req.StartTime - source.StartTime
+	contest := source.Contest
+	contest.RegistrationStartTime += shift
+	contest.RegistrationEndTime += shift
+	contest.StartTime += shift
+	contest.EndTime += shift
+	contest.IsTemplate = false
+	if req.Name != "" {
+		contest.Name = req.Name
+	}
+
+	if err := cs.copyContest(ctx, sourceID, &contest); err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
+// SaveAsTemplate copies a contest into a reusable template
+func (cs *ContestService) SaveAsTemplate(ctx context.Context, sourceID string, name string) (*models.Contest, error) {
+	source, err := cs.stores.Contests.GetContest(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := source.Contest
+	template.Name = name
+	template.IsTemplate = true
+
+	if err := cs.copyContest(ctx, sourceID, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (cs *ContestService) ListTemplates(ctx context.Context, page int) ([]models.Contest, error) {
+	return cs.stores.Contests.ListTemplates(ctx, page)
+}
+
+// InstantiateTemplate creates a new draft contest from a template with new dates and eligibility
+func (cs *ContestService) InstantiateTemplate(ctx context.Context, templateID string, req *dto.InstantiateTemplateRequest) (*models.Contest, error) {
+	template, err := cs.stores.Contests.GetContest(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return nil, common.TemplateNotFoundError
+		}
+		return nil, err
+	}
+
+	if !template.IsTemplate {
+		return nil, common.TemplateNotFoundError
+	}
+
+	contest := template.Contest
+	contest.Name = req.Name
+	contest.RegistrationStartTime = req.RegistrationStartTime
+	contest.RegistrationEndTime = req.RegistrationEndTime
+	contest.StartTime = req.StartTime
+	contest.EndTime = req.EndTime
+	contest.EligibleTo = req.EligibleTo
+	contest.IsTemplate = false
+
+	if err := cs.copyContest(ctx, templateID, &contest); err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
+// copyContest creates dest as a new draft contest holding copies of the problems,
+// test cases, S3 assets, sections and pools of the source contest
+func (cs *ContestService) copyContest(ctx context.Context, sourceID string, dest *models.Contest) error {
+	id, err := gonanoid.Generate(contestIDAlphabet, 10)
+	if err != nil {
+		log.Errorf("failed to generate contest ID: %v", err)
+		return err
+	}
+	dest.ID = id
+	dest.Status = models.ContestDraft
+
+	problems, err := cs.stores.Problems.ListProblems(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	pools, err := cs.stores.ProblemSets.ListPools(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	sections, err := cs.stores.Sections.ListSections(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+
+	sectionIDs := make(map[string]string, len(sections))
+	for i := range sections {
+		sectionIDs[sections[i].ID] = uuid.NewString()
+		sections[i].ID = sectionIDs[sections[i].ID]
+	}
+
+	var testCases []models.TestCase
+	for i := range problems {
+		sourceProblemID := problems[i].ID
+		problems[i].ID = uuid.NewString()
+		problems[i].ContestID = dest.ID
+		problems[i].SectionID = sectionIDs[problems[i].SectionID]
+
+		sourceTestCases, err := cs.stores.Problems.ListTestCases(ctx, sourceProblemID)
+		if err != nil {
+			return err
+		}
+
+		for _, tc := range sourceTestCases {
+			copied := models.TestCase{
+				ID:        uuid.NewString(),
+				ProblemID: problems[i].ID,
+				IsSample:  tc.IsSample,
+				CreatedAt: tc.CreatedAt,
+			}
+
+			// Copy the files first, orphaned objects are harmless if the insert fails
+			if err := cs.s3.CopyObject(ctx, testCaseInputKey(sourceProblemID, tc.ID), testCaseInputKey(copied.ProblemID, copied.ID)); err != nil {
+				return err
+			}
+			if err := cs.s3.CopyObject(ctx, testCaseOutputKey(sourceProblemID, tc.ID), testCaseOutputKey(copied.ProblemID, copied.ID)); err != nil {
+				return err
+			}
+
+			testCases = append(testCases, copied)
+		}
+
+		for j := range problems[i].Assets {
+			asset := &problems[i].Assets[j]
+			sourceAssetID := asset.ID
+			asset.ID = uuid.NewString()
+			asset.ProblemID = problems[i].ID
+
+			if err := cs.s3.CopyObject(ctx, assetKey(sourceProblemID, sourceAssetID), assetKey(asset.ProblemID, asset.ID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return cs.stores.Contests.CreateContestWithProblems(ctx, dest, problems, testCases, pools, sections)
+}
+
+// ExportContest collects a contest, its problems and test case files into a bundle
+func (cs *ContestService) ExportContest(ctx context.Context, contestID string) (*bundle.Bundle, error) {
+	contest, err := cs.stores.Contests.GetContest(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	problems, err := cs.stores.Problems.ListProblems(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	pools, err := cs.stores.ProblemSets.ListPools(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := cs.stores.Sections.ListSections(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bundle.Bundle{Contest: contest.Contest, Pools: pools, Sections: sections}
+	for _, problem := range problems {
+		testCases, err := cs.stores.Problems.ListTestCases(ctx, problem.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		bp := bundle.Problem{Problem: problem}
+		for _, asset := range problem.Assets {
+			data, err := cs.s3.GetObject(ctx, assetKey(problem.ID, asset.ID))
+			if err != nil {
+				return nil, err
+			}
+
+			bp.AssetFiles = append(bp.AssetFiles, bundle.Asset{
+				Name:        asset.Name,
+				ContentType: asset.ContentType,
+				Data:        data,
+			})
+		}
+		for _, tc := range testCases {
+			input, err := cs.s3.GetObject(ctx, testCaseInputKey(problem.ID, tc.ID))
+			if err != nil {
+				return nil, err
+			}
+			output, err := cs.s3.GetObject(ctx, testCaseOutputKey(problem.ID, tc.ID))
+			if err != nil {
+				return nil, err
+			}
+
+			bp.TestCases = append(bp.TestCases, bundle.TestCase{
+				IsSample: tc.IsSample,
+				Input:    input,
+				Output:   output,
+			})
+		}
+
+		b.Problems = append(b.Problems, bp)
+	}
+
+	return b, nil
+}
+
+// ImportContest recreates a bundled contest as a draft, keeping the contest and problem IDs.
+// With dryRun set nothing is written and only the conflicts are reported.
+func (cs *ContestService) ImportContest(ctx context.Context, b *bundle.Bundle, dryRun bool) (*dto.ImportContestResponse, error) {
+	if b.Contest.ID == "" || b.Contest.Name == "" {
+		return nil, fmt.Errorf("%w: contest id and name are required", common.InvalidBundleError)
+	}
+
+	res := &dto.ImportContestResponse{
+		ContestID: b.Contest.ID,
+		DryRun:    dryRun,
+		Problems:  len(b.Problems),
+		Conflicts: []string{},
+	}
+
+	_, err := cs.stores.Contests.GetContest(ctx, b.Contest.ID)
+	if err == nil {
+		res.Conflicts = append(res.Conflicts, fmt.Sprintf("contest %s already exists", b.Contest.ID))
+	} else if !errors.Is(err, common.ContestNotFoundError) {
+		return nil, err
+	}
+
+	problemIDs := make([]string, len(b.Problems))
+	for i, p := range b.Problems {
+		if p.ID == "" || p.Name == "" || (p.Type != models.Code && p.Type != models.MCQ) {
+			return nil, fmt.Errorf("%w: problem %d is missing an id, name or valid type", common.InvalidBundleError, i+1)
+		}
+		problemIDs[i] = p.ID
+		res.TestCases += len(p.TestCases)
+	}
+
+	existing, err := cs.stores.Problems.ListExistingProblemIDs(ctx, problemIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range existing {
+		res.Conflicts = append(res.Conflicts, fmt.Sprintf("problem %s already exists", id))
+	}
+
+	if dryRun {
+		return res, nil
+	}
+	if len(res.Conflicts) > 0 {
+		return res, common.ImportConflictError
+	}
+
+	contest := b.Contest
+	contest.Status = models.ContestDraft
+
+	// Section IDs in a bundle only link problems to sections, new ones are generated
+	sections := slices.Clone(b.Sections)
+	sectionIDs := make(map[string]string, len(sections))
+	for i := range sections {
+		sectionIDs[sections[i].ID] = uuid.NewString()
+		sections[i].ID = sectionIDs[sections[i].ID]
+	}
+
+	problems := make([]models.Problem, 0, len(b.Problems))
+	var testCases []models.TestCase
+	for _, p := range b.Problems {
+		p.ContestID = contest.ID
+		p.SectionID = sectionIDs[p.SectionID]
+		p.Assets = nil
+
+		for _, a := range p.AssetFiles {
+			asset := models.ProblemAsset{
+				ID:          uuid.NewString(),
+				ProblemID:   p.ID,
+				Name:        a.Name,
+				ContentType: a.ContentType,
+				Size:        int64(len(a.Data)),
+				CreatedAt:   time.Now().Unix(),
+			}
+
+			if err := cs.s3.PutObject(ctx, assetKey(p.ID, asset.ID), a.Data); err != nil {
+				return nil, err
+			}
+
+			p.Assets = append(p.Assets, asset)
+		}
+
+		problems = append(problems, p.Problem)
+
+		for _, tc := range p.TestCases {
+			testCase := models.TestCase{
+				ID:        uuid.NewString(),
+				ProblemID: p.ID,
+				IsSample:  tc.IsSample,
+				CreatedAt: time.Now().Unix(),
+			}
+
+			if err := cs.s3.PutObject(ctx, testCaseInputKey(p.ID, testCase.ID), tc.Input); err != nil {
+				return nil, err
+			}
+			if err := cs.s3.PutObject(ctx, testCaseOutputKey(p.ID, testCase.ID), tc.Output); err != nil {
+				return nil, err
+			}
+
+			testCases = append(testCases, testCase)
+		}
+	}
+
+	if err := cs.stores.Contests.CreateContestWithProblems(ctx, &contest, problems, testCases, b.Pools, sections); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (cs *ContestService) DeleteContest(ctx context.Context, contestID string) error {
 	return cs.stores.Contests.DeleteContest(ctx, contestID)
 }
@@ -46,106 +475,1705 @@ func (cs *ContestService) RegisterParticipant(contestID string, userID string) e
 	return nil
 }
 
-func (cs *ContestService) ModifyRegistration(ctx context.Context, contestID string, userID string, action dto.RegisterationAction) error {
+// ModifyRegistration registers or unregisters a user. A registration to a full contest joins
+// the waitlist, in which case the user's place on it is returned.
+func (cs *ContestService) ModifyRegistration(ctx context.Context, contestID string, userID string, action dto.RegisterationAction, inviteCode string) (*dto.ModifyRegistrationResponse, error) {
 	contest, err := cs.stores.Contests.GetContest(ctx, contestID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if contest.Status == models.ContestDraft {
+		return nil, common.ContestNotFoundError
 	}
 
 	if contest.GetRegistrationStatus() != models.ContestRegistrationOpen {
 		log.Errorf("contest %s is not open for registration", contestID)
-		return common.ContestRegistrationClosedError
+		return nil, common.ContestRegistrationClosedError
+	}
+
+	if contest.TeamMode {
+		return nil, common.TeamRegistrationRequiredError
 	}
 
 	switch action {
 	case dto.RegisterAction:
-		user, err := cs.stores.Users.GetUserProfile(ctx, userID)
+		access, err := cs.stores.Contests.GetAccess(ctx, contestID, userID)
 		if err != nil {
-			log.Errorf("failed to get user profile for user %s: %v", userID, err)
-			return err
+			return nil, err
+		}
+
+		// The code is only checked here, it is redeemed along with the registration
+		inviteCode = strings.ToUpper(strings.TrimSpace(inviteCode))
+		if inviteCode != "" {
+			if err := cs.checkInviteCode(ctx, contestID, userID, inviteCode); err != nil {
+				log.Errorf("user %s failed to redeem invite code for contest %s: %v", userID, contestID, err)
+				return nil, err
+			}
+			if access != models.AccessDeny {
+				access = models.AccessAllow
+			}
 		}
 
-		if !slices.Contains(contest.EligibleTo, user.CurrentYear) {
-			log.Errorf("user %s is not eligible to contest %s", userID, contestID)
-			return common.InvalidYearError
+		result, err := cs.checkEligibilityWithAccess(ctx, &contest.Contest, userID, access)
+		if err != nil {
+			log.Errorf("failed to check eligibility of user %s: %v", userID, err)
+			return nil, err
+		}
+
+		if !result.Eligible {
+			log.Errorf("user %s is not eligible to contest %s: %s", userID, contestID, result.Reason)
+			return nil, fmt.Errorf("%w: %s", common.NotEligibleError, result.Reason)
+		}
+
+		waitlisted, err := cs.stores.Contests.RegisterUser(ctx, contestID, userID, inviteCode)
+		if err != nil || !waitlisted {
+			return nil, err
 		}
 
-		return cs.stores.Contests.RegisterUser(ctx, contestID, userID)
+		position, err := cs.stores.Contests.GetWaitlistPosition(ctx, contestID, userID)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.ModifyRegistrationResponse{Waitlisted: true, WaitlistPosition: position}, nil
 
 	case dto.UnregisterAction:
-		return cs.stores.Contests.UnregisterUser(ctx, contestID, userID)
+		// Unregistering would allow the user to restart their attempt
+		if contest.IsTimed() {
+			startedAt, err := cs.stores.Contests.GetAttemptStart(ctx, contestID, userID)
+			if err != nil && !errors.Is(err, common.UserNotRegisteredError) {
+				return nil, err
+			}
+			if startedAt > 0 {
+				return nil, common.AttemptAlreadyStartedError
+			}
+		}
+
+		return nil, cs.stores.Contests.UnregisterUser(ctx, contestID, userID)
 
 	default:
-		return fmt.Errorf("invalid action: %s", action)
+		return nil, fmt.Errorf("invalid action: %s", action)
 	}
 }
 
-func (cs *ContestService) ListContests(ctx context.Context, page int) ([]models.Contest, error) {
-	return cs.stores.Contests.ListContests(ctx, page)
+// checkEligibility runs the eligibility engine for a user, then the recruitment drive the contest
+// is a round of. Users without a profile are never eligible.
+func (cs *ContestService) checkEligibility(ctx context.Context, contest *models.Contest, userID string) (*eligibility.Result, error) {
+	access, err := cs.stores.Contests.GetAccess(ctx, contest.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.checkEligibilityWithAccess(ctx, contest, userID, access)
 }
 
-//Problem Reated Services
+// checkEligibilityWithAccess checks eligibility as if the user had the given access list entry
+func (cs *ContestService) checkEligibilityWithAccess(ctx context.Context, contest *models.Contest, userID string, access models.ContestAccess) (*eligibility.Result, error) {
+	user, err := cs.stores.Users.GetUserProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, common.UserNotFoundError) {
+			return &eligibility.Result{Reason: "complete your profile to register"}, nil
+		}
+		return nil, err
+	}
 
-func (cs *ContestService) CreateProblem(ctx context.Context, problem *models.Problem) (*models.Problem, error) {
+	result := eligibility.Check(contest, user, access)
 
-	problem.ID = uuid.NewString()
+	// Later rounds of a recruitment drive are open to the users shortlisted in the round before
+	if result.Eligible && access != models.AccessAllow {
+		promoted, err := cs.stores.Drives.IsPromoted(ctx, contest.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !promoted {
+			result = eligibility.Result{Reason: "you were not shortlisted in the previous round of this drive"}
+		}
+	}
 
-	if err := cs.stores.Problems.CreateProblem(ctx, problem); err != nil {
+	return &result, nil
+}
+
+// ListAccess lists the users explicitly allowed or denied registering for a contest
+func (cs *ContestService) ListAccess(ctx context.Context, contestID string) ([]models.AccessListEntry, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
 		return nil, err
 	}
 
-	return problem, nil
+	return cs.stores.Contests.ListAccess(ctx, contestID)
 }
 
-func (cs *ContestService) UpdateProblem(ctx context.Context, problem *models.Problem) (*models.Problem, error) {
-	if err := cs.stores.Problems.UpdateProblem(ctx, problem); err != nil {
+func (cs *ContestService) SetAccess(ctx context.Context, contestID string, userID string, adminID string, access models.ContestAccess) (*models.AccessListEntry, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
 		return nil, err
 	}
-	return problem, nil
+
+	if _, err := cs.stores.Users.GetUserProfile(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	entry := &models.AccessListEntry{
+		ContestID: contestID,
+		UserID:    userID,
+		Access:    access,
+		AddedBy:   adminID,
+		AddedAt:   time.Now().Unix(),
+	}
+
+	if err := cs.stores.Contests.SetAccess(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
 }
 
-func (cs *ContestService) DeleteProblem(ctx context.Context, contestID string, problemID string) error {
-	return cs.stores.Problems.DeleteProblem(ctx, contestID, problemID)
+func (cs *ContestService) DeleteAccess(ctx context.Context, contestID string, userID string) error {
+	return cs.stores.Contests.DeleteAccess(ctx, contestID, userID)
 }
 
-//Leaderboard related services
+func (cs *ContestService) ListWaitlist(ctx context.Context, contestID string) ([]models.WaitlistEntry, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
 
-func (cs *ContestService) UpdateLeaderboardUser(ctx context.Context, contestID string, userID string, req *dto.UpdateLeaderboardUserRequest) error {
-	return cs.stores.Rankings.UpdateLeaderboardUser(ctx, contestID, userID, req)
+	return cs.stores.Contests.ListWaitlist(ctx, contestID)
 }
 
-func (cs *ContestService) GetProblemVisibility(ctx context.Context, contestID string, userID string) error {
-
-	contest, err := cs.GetContest(ctx, contestID, userID)
+// checkInviteCode checks that the user can redeem an invite code. Registered users are turned
+// away before the code is looked at.
+func (cs *ContestService) checkInviteCode(ctx context.Context, contestID string, userID string, code string) error {
+	registered, err := cs.stores.Contests.IsRegistered(ctx, contestID, userID)
 	if err != nil {
 		return err
 	}
-
-	if contest.IsRegistered == nil || !*contest.IsRegistered {
-		return common.UserNotRegisteredError
+	if registered {
+		return common.UserAlreadyExistsError
 	}
 
-	if contest.GetRunningStatus() == models.ContestRunningUpcoming {
-		return common.ContestNotRunningError
+	// The allow list only holds users with a profile
+	if _, err := cs.stores.Users.GetUserProfile(ctx, userID); err != nil {
+		if errors.Is(err, common.UserNotFoundError) {
+			return fmt.Errorf("%w: %s", common.NotEligibleError, "complete your profile to register")
+		}
+		return err
 	}
 
-	return nil
+	return cs.stores.Invites.CheckInviteCode(ctx, contestID, code, userID, time.Now().UnixMilli())
 }
 
-func (cs *ContestService) GetContestProblemsList(ctx context.Context, contestID string) ([]dto.ProblemOverview, error) {
-	return cs.stores.Problems.GetProblemList(ctx, contestID)
-}
+func (cs *ContestService) ListInviteCodes(ctx context.Context, contestID string) ([]models.InviteCode, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
 
-func (cs *ContestService) GetContestProblem(ctx context.Context, contestID string, problemID string) (*dto.GetProblemStatementResponse, error) {
-	return cs.stores.Problems.GetProblem(ctx, problemID, contestID)
+	return cs.stores.Invites.ListInviteCodes(ctx, contestID)
 }
 
-func (cs *ContestService) GetContest(ctx context.Context, contestID string, userID string) (*dto.GetContestResponse, error) {
-	contest_response, err := cs.stores.Contests.GetContest(ctx, contestID)
+// CreateInviteCode generates a code registering up to maxUses users, any number if 0, until expiresAt
+func (cs *ContestService) CreateInviteCode(ctx context.Context, contestID string, adminID string, maxUses int, expiresAt int64) (*models.InviteCode, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	code, err := gonanoid.Generate(inviteCodeAlphabet, 8)
 	if err != nil {
+		log.Errorf("failed to generate invite code: %v", err)
 		return nil, err
 	}
 
+	invite := &models.InviteCode{
+		Code:      code,
+		ContestID: contestID,
+		MaxUses:   maxUses,
+		ExpiresAt: expiresAt,
+		CreatedBy: adminID,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	if err := cs.stores.Invites.CreateInviteCode(ctx, invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (cs *ContestService) DeleteInviteCode(ctx context.Context, contestID string, code string) error {
+	return cs.stores.Invites.DeleteInviteCode(ctx, contestID, strings.ToUpper(code))
+}
+
+// InviteShortlisted allows the users shortlisted in the source contest to register for the contest
+func (cs *ContestService) InviteShortlisted(ctx context.Context, contestID string, sourceContestID string, adminID string) (int, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return 0, err
+	}
+
+	if _, err := cs.stores.Contests.GetContest(ctx, sourceContestID); err != nil {
+		return 0, err
+	}
+
+	return cs.stores.Invites.InviteShortlisted(ctx, contestID, sourceContestID, adminID, time.Now().Unix())
+}
+
+// ListContests lists the published contests, leaving out private ones
+func (cs *ContestService) ListContests(ctx context.Context, page int) ([]models.Contest, error) {
+	return cs.stores.Contests.ListContests(ctx, page, []models.ContestStatus{models.ContestPublished}, false)
+}
+
+// ListPastContests lists the archived contests, leaving out private ones
+func (cs *ContestService) ListPastContests(ctx context.Context, page int) ([]models.Contest, error) {
+	return cs.stores.Contests.ListContests(ctx, page, []models.ContestStatus{models.ContestArchived}, false)
+}
+
+// ListAllContests lists contests regardless of status unless one is given, for admins
+func (cs *ContestService) ListAllContests(ctx context.Context, page int, status models.ContestStatus) ([]models.Contest, error) {
+	var statuses []models.ContestStatus
+	if status != "" {
+		statuses = append(statuses, status)
+	}
+	return cs.stores.Contests.ListContests(ctx, page, statuses, true)
+}
+
+//Problem Reated Services
+
+// CreateProblem creates a problem. MCQ options given inline are numbered in the order
+// they are listed, starting at 1, and the answer must refer to those numbers.
+func (cs *ContestService) CreateProblem(ctx context.Context, problem *models.Problem) (*models.Problem, error) {
+
+	if err := prepareNewProblem(problem); err != nil {
+		return nil, err
+	}
+
+	if err := cs.placeProblem(ctx, problem); err != nil {
+		return nil, err
+	}
+
+	if err := cs.stores.Problems.CreateProblem(ctx, problem); err != nil {
+		return nil, err
+	}
+
+	return problem, nil
+}
+
+// prepareNewProblem assigns the problem a fresh ID and numbers its inline MCQ options
+func prepareNewProblem(problem *models.Problem) error {
+	problem.ID = uuid.NewString()
+	problem.Assets = nil // Uploaded separately
+
+	if problem.Type != models.MCQ {
+		problem.Options = nil
+	}
+	for i := range problem.Options {
+		problem.Options[i].ID = i + 1
+		problem.Options[i].Position = i + 1
+		problem.Options[i].Weight = max(problem.Options[i].Weight, 1)
+	}
+
+	return validateAnswer(problem, problem.Options)
+}
+
+// placeProblem validates the problem's section and defaults its position and label within the contest
+func (cs *ContestService) placeProblem(ctx context.Context, problem *models.Problem) error {
+	if err := cs.validateSection(ctx, problem); err != nil {
+		return err
+	}
+
+	// New problems go last unless placed explicitly
+	if problem.Position <= 0 {
+		count, err := cs.stores.Problems.CountProblems(ctx, problem.ContestID)
+		if err != nil {
+			return err
+		}
+		problem.Position = count + 1
+	}
+	if problem.Label == "" {
+		problem.Label = problemLabel(dto.LetterLabels, problem.Position)
+	}
+
+	return nil
+}
+
+// AttachProblem adds a problem of the bank to a contest
+func (cs *ContestService) AttachProblem(ctx context.Context, contestID string, req *dto.AttachProblemRequest, grants models.Grants) (*models.Problem, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	bp, err := cs.stores.Problems.GetBankProblem(ctx, req.ProblemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemScope(ctx, req.ProblemID, grants); err != nil {
+		return nil, err
+	}
+
+	problem := bp.Problem
+	problem.ContestID = contestID
+	problem.Label = req.Label
+	problem.SectionID = req.SectionID
+	problem.Pool = req.Pool
+	if req.Score != nil {
+		problem.Score = *req.Score
+	}
+
+	if err := cs.placeProblem(ctx, &problem); err != nil {
+		return nil, err
+	}
+
+	if err := cs.stores.Problems.AttachProblem(ctx, &problem, req.Score); err != nil {
+		return nil, err
+	}
+
+	return &problem, nil
+}
+
+// checkProblemNotShared returns ProblemSharedError if a contest other than contestID uses the
+// problem. Its statement, options, test cases and assets are shared by every contest using it,
+// so they are then only edited through the bank.
+func (cs *ContestService) checkProblemNotShared(ctx context.Context, contestID string, problemID string) error {
+	contestIDs, err := cs.stores.Problems.ListProblemContests(ctx, problemID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range contestIDs {
+		if id != contestID {
+			return common.ProblemSharedError
+		}
+	}
+	return nil
+}
+
+// checkProblemScope returns ProblemOutOfScopeError if a contest outside the admin's grants uses
+// the problem. Problems are shared, so admins scoped to some contests may only attach or edit
+// problems that no contest outside their scope uses.
+func (cs *ContestService) checkProblemScope(ctx context.Context, problemID string, grants models.Grants) error {
+	if grants.Can(models.PermProblemsWrite, "") {
+		return nil
+	}
+
+	contestIDs, err := cs.stores.Problems.ListProblemContests(ctx, problemID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range contestIDs {
+		if !grants.Can(models.PermProblemsWrite, id) {
+			return common.ProblemOutOfScopeError
+		}
+	}
+	return nil
+}
+
+// Problem Bank Services
+
+func (cs *ContestService) ListBankProblems(ctx context.Context, req *dto.ListBankProblemsRequest) ([]dto.BankProblem, error) {
+	return cs.stores.Problems.ListBankProblems(ctx, req.Tag, req.Difficulty, req.Page)
+}
+
+func (cs *ContestService) GetBankProblem(ctx context.Context, problemID string) (*dto.BankProblem, error) {
+	return cs.stores.Problems.GetBankProblem(ctx, problemID)
+}
+
+// CreateBankProblem adds a problem to the bank. Options are numbered as in CreateProblem.
+func (cs *ContestService) CreateBankProblem(ctx context.Context, problem *models.Problem) (*models.Problem, error) {
+	if err := prepareNewProblem(problem); err != nil {
+		return nil, err
+	}
+
+	if err := cs.stores.Problems.CreateBankProblem(ctx, problem); err != nil {
+		return nil, err
+	}
+
+	return problem, nil
+}
+
+// UpdateBankProblem updates a problem of the bank, which every contest using it sees
+func (cs *ContestService) UpdateBankProblem(ctx context.Context, problem *models.Problem) (*models.Problem, error) {
+	existing, err := cs.stores.Problems.GetBankProblem(ctx, problem.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if problem.Type == models.MCQ {
+		if err := validateAnswer(problem, existing.Options); err != nil {
+			return nil, err
+		}
+		problem.Options = existing.Options
+	}
+	problem.Assets = existing.Assets
+
+	if err := cs.stores.Problems.UpdateBankProblem(ctx, problem); err != nil {
+		return nil, err
+	}
+	return problem, nil
+}
+
+func (cs *ContestService) DeleteBankProblem(ctx context.Context, problemID string) error {
+	return cs.stores.Problems.DeleteBankProblem(ctx, problemID)
+}
+
+// UpdateProblem updates a problem of a contest. Its statement, answer and marking can only be
+// changed here while no other contest uses it, the contest specific fields always can.
+func (cs *ContestService) UpdateProblem(ctx context.Context, problem *models.Problem, grants models.Grants) (*models.Problem, error) {
+	existing, err := cs.stores.Problems.GetProblemDetails(ctx, problem.ID, problem.ContestID)
+	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return nil, common.ProblemNotFoundError
+		}
+		return nil, err
+	}
+
+	if err := cs.checkProblemScope(ctx, problem.ID, grants); err != nil {
+		return nil, err
+	}
+
+	if problem.Name != existing.Name ||
+		problem.Description != existing.Description ||
+		problem.Type != existing.Type ||
+		!slices.Equal(problem.Answer, existing.Answer) ||
+		problem.MultipleChoice != existing.MultipleChoice ||
+		problem.NegativeMarks != existing.NegativeMarks ||
+		problem.PartialCredit != existing.PartialCredit {
+		if err := cs.checkProblemNotShared(ctx, problem.ContestID, problem.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if problem.Type == models.MCQ {
+		options, err := cs.stores.Problems.ListOptions(ctx, problem.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validateAnswer(problem, options); err != nil {
+			return nil, err
+		}
+		problem.Options = options
+	}
+
+	if err := cs.validateSection(ctx, problem); err != nil {
+		return nil, err
+	}
+
+	if err := cs.stores.Problems.UpdateProblem(ctx, problem); err != nil {
+		return nil, err
+	}
+	return problem, nil
+}
+
+// validateSection checks that the problem's section belongs to its contest
+func (cs *ContestService) validateSection(ctx context.Context, problem *models.Problem) error {
+	if problem.SectionID == "" {
+		return nil
+	}
+
+	sections, err := cs.stores.Sections.ListSections(ctx, problem.ContestID)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(sections, func(s models.ContestSection) bool { return s.ID == problem.SectionID }) {
+		return common.SectionNotFoundError
+	}
+
+	return nil
+}
+
+// problemLabel returns the label of the problem at a 1-based position: A, B, ... Z, AA, AB...
+// for letters and Q1, Q2... for numbers
+func problemLabel(style dto.LabelStyle, position int) string {
+	if style == dto.NumberLabels {
+		return fmt.Sprintf("Q%d", position)
+	}
+
+	label := ""
+	for n := position; n > 0; n = (n - 1) / 26 {
+		label = string(rune('A'+(n-1)%26)) + label
+	}
+	return label
+}
+
+// ReorderProblems sets the order of the problems of a contest, optionally relabelling them to match
+func (cs *ContestService) ReorderProblems(ctx context.Context, contestID string, req *dto.ReorderProblemsRequest) ([]dto.ProblemOverview, error) {
+	count, err := cs.stores.Problems.CountProblems(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.ProblemIDs))
+	for _, id := range req.ProblemIDs {
+		if seen[id] {
+			return nil, common.InvalidProblemOrderError
+		}
+		seen[id] = true
+	}
+	if len(req.ProblemIDs) != count {
+		return nil, common.InvalidProblemOrderError
+	}
+
+	var labels []string
+	if req.LabelStyle != "" {
+		labels = make([]string, len(req.ProblemIDs))
+		for i := range labels {
+			labels[i] = problemLabel(req.LabelStyle, i+1)
+		}
+	}
+
+	if err := cs.stores.Problems.ReorderProblems(ctx, contestID, req.ProblemIDs, labels); err != nil {
+		return nil, err
+	}
+
+	return cs.stores.Problems.GetProblemList(ctx, contestID)
+}
+
+func (cs *ContestService) ListSections(ctx context.Context, contestID string) ([]models.ContestSection, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	return cs.stores.Sections.ListSections(ctx, contestID)
+}
+
+func (cs *ContestService) CreateSection(ctx context.Context, contestID string, req *dto.UpsertSectionRequest) (*models.ContestSection, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	section := &models.ContestSection{
+		ID:           uuid.NewString(),
+		ContestID:    contestID,
+		Name:         req.Name,
+		Instructions: req.Instructions,
+		Position:     req.Position,
+	}
+
+	if err := cs.stores.Sections.CreateSection(ctx, section); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+func (cs *ContestService) UpdateSection(ctx context.Context, contestID string, sectionID string, req *dto.UpsertSectionRequest) (*models.ContestSection, error) {
+	section := &models.ContestSection{
+		ID:           sectionID,
+		ContestID:    contestID,
+		Name:         req.Name,
+		Instructions: req.Instructions,
+		Position:     req.Position,
+	}
+
+	if err := cs.stores.Sections.UpdateSection(ctx, section); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+func (cs *ContestService) DeleteSection(ctx context.Context, contestID string, sectionID string) error {
+	return cs.stores.Sections.DeleteSection(ctx, contestID, sectionID)
+}
+
+// validateAnswer checks that an MCQ answer refers to existing options, and to at most
+// one of them for single-choice problems. Problems without structured options keep
+// their options in the description and are not checked.
+func validateAnswer(problem *models.Problem, options []models.ProblemOption) error {
+	if problem.Type != models.MCQ || len(options) == 0 {
+		return nil
+	}
+
+	if !problem.MultipleChoice && len(problem.Answer) > 1 {
+		return common.InvalidAnswerError
+	}
+
+	if !models.ContainsOptions(options, problem.Answer) {
+		return common.InvalidAnswerError
+	}
+
+	return nil
+}
+
+func (cs *ContestService) ListOptions(ctx context.Context, contestID string, problemID string) ([]models.ProblemOption, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	return cs.stores.Problems.ListOptions(ctx, problemID)
+}
+
+func (cs *ContestService) CreateOption(ctx context.Context, contestID string, problemID string, req *dto.UpsertOptionRequest, grants models.Grants) (*models.ProblemOption, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemNotShared(ctx, contestID, problemID); err != nil {
+		return nil, err
+	}
+
+	option := &models.ProblemOption{
+		Position: req.Position,
+		Text:     req.Text,
+		Image:    req.Image,
+		Weight:   max(req.Weight, 1),
+	}
+
+	if err := cs.stores.Problems.CreateOption(ctx, problemID, option); err != nil {
+		return nil, err
+	}
+
+	return option, nil
+}
+
+func (cs *ContestService) UpdateOption(ctx context.Context, contestID string, problemID string, optionID int, req *dto.UpsertOptionRequest, grants models.Grants) (*models.ProblemOption, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemNotShared(ctx, contestID, problemID); err != nil {
+		return nil, err
+	}
+
+	option := &models.ProblemOption{
+		ID:       optionID,
+		Position: req.Position,
+		Text:     req.Text,
+		Image:    req.Image,
+		Weight:   max(req.Weight, 1),
+	}
+
+	if err := cs.stores.Problems.UpdateOption(ctx, problemID, option); err != nil {
+		return nil, err
+	}
+
+	return option, nil
+}
+
+func (cs *ContestService) DeleteOption(ctx context.Context, contestID string, problemID string, optionID int, grants models.Grants) error {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return err
+	}
+
+	if err := cs.checkProblemNotShared(ctx, contestID, problemID); err != nil {
+		return err
+	}
+
+	return cs.stores.Problems.DeleteOption(ctx, problemID, optionID)
+}
+
+func (cs *ContestService) DeleteProblem(ctx context.Context, contestID string, problemID string) error {
+	return cs.stores.Problems.DeleteProblem(ctx, contestID, problemID)
+}
+
+// Editorial Services
+
+func (cs *ContestService) GetEditorial(ctx context.Context, contestID string, problemID string) (*models.ProblemEditorial, error) {
+	return cs.stores.Editorials.GetEditorial(ctx, contestID, problemID)
+}
+
+func (cs *ContestService) UpsertEditorial(ctx context.Context, contestID string, problemID string, content string, grants models.Grants) (*models.ProblemEditorial, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return nil, err
+	}
+
+	editorial := &models.ProblemEditorial{
+		ContestID: contestID,
+		ProblemID: problemID,
+		Content:   content,
+		UpdatedAt: time.Now().Unix(),
+	}
+
+	if err := cs.stores.Editorials.UpsertEditorial(ctx, editorial); err != nil {
+		return nil, err
+	}
+	return editorial, nil
+}
+
+func (cs *ContestService) DeleteEditorial(ctx context.Context, contestID string, problemID string, grants models.Grants) error {
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return err
+	}
+
+	return cs.stores.Editorials.DeleteEditorial(ctx, contestID, problemID)
+}
+
+func (cs *ContestService) ListSolutions(ctx context.Context, contestID string, problemID string) ([]models.ReferenceSolution, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	return cs.stores.Editorials.ListSolutions(ctx, contestID, problemID)
+}
+
+func (cs *ContestService) CreateSolution(ctx context.Context, contestID string, problemID string, req *dto.CreateSolutionRequest, grants models.Grants) (*models.ReferenceSolution, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return nil, err
+	}
+
+	solution := &models.ReferenceSolution{
+		ID:          uuid.NewString(),
+		ContestID:   contestID,
+		ProblemID:   problemID,
+		Language:    req.Language,
+		Code:        req.Code,
+		Explanation: req.Explanation,
+		CreatedAt:   time.Now().Unix(),
+	}
+
+	if err := cs.stores.Editorials.CreateSolution(ctx, solution); err != nil {
+		return nil, err
+	}
+	return solution, nil
+}
+
+func (cs *ContestService) DeleteSolution(ctx context.Context, contestID string, problemID string, solutionID string, grants models.Grants) error {
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return err
+	}
+
+	return cs.stores.Editorials.DeleteSolution(ctx, contestID, problemID, solutionID)
+}
+
+// GetProblemEditorial returns the editorial and reference solutions of a problem to a
+// user who can see the problem, once the contest has ended
+func (cs *ContestService) GetProblemEditorial(ctx context.Context, contestID string, problemID string, userID string) (*dto.ProblemEditorialResponse, error) {
+	contest, err := cs.stores.Contests.GetContest(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if contest.GetRunningStatus() != models.ContestRunningClosed {
+		return nil, common.EditorialNotAvailableError
+	}
+
+	// Check visibility before the problem set lookup, which draws and stores a set for
+	// users who do not have one yet
+	if err := cs.GetProblemVisibility(ctx, contestID, userID); err != nil {
+		switch err {
+		case common.UserNotRegisteredError,
+			common.ContestNotRunningError,
+			common.AttemptNotStartedError,
+			common.AttemptExpiredError:
+			return nil, common.ProblemNotFoundError
+		}
+		return nil, err
+	}
+
+	if err := cs.CheckProblemInSet(ctx, contestID, userID, problemID); err != nil {
+		return nil, err
+	}
+
+	editorial, err := cs.stores.Editorials.GetEditorial(ctx, contestID, problemID)
+	if err != nil && err != common.EditorialNotFoundError {
+		return nil, err
+	}
+
+	solutions, err := cs.stores.Editorials.ListSolutions(ctx, contestID, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if editorial == nil && len(solutions) == 0 {
+		return nil, common.EditorialNotFoundError
+	}
+
+	return &dto.ProblemEditorialResponse{
+		Editorial: editorial,
+		Solutions: solutions,
+	}, nil
+}
+
+func testCaseInputKey(problemID string, testCaseID string) string {
+	return fmt.Sprintf("problems/%s/testcases/%s/input", problemID, testCaseID)
+}
+
+func testCaseOutputKey(problemID string, testCaseID string) string {
+	return fmt.Sprintf("problems/%s/testcases/%s/output", problemID, testCaseID)
+}
+
+func assetKey(problemID string, assetID string) string {
+	return fmt.Sprintf("problems/%s/assets/%s", problemID, assetID)
+}
+
+// assetLinkPrefix is how descriptions reference assets of their problem, as asset://<name>
+const assetLinkPrefix = "asset://"
+
+// assetLinkExpiry is how long the asset links of a served statement stay valid
+const assetLinkExpiry = 15 * time.Minute
+
+// rewriteAssetLinks points asset references in a description to presigned S3 URLs, which
+// browsers can load in image and link tags without credentials. References to unknown
+// assets are left as they are.
+func (cs *ContestService) rewriteAssetLinks(ctx context.Context, description string, problemID string) (string, error) {
+	if !strings.Contains(description, assetLinkPrefix) {
+		return description, nil
+	}
+
+	assets, err := cs.stores.Problems.ListAssets(ctx, problemID)
+	if err != nil {
+		return "", err
+	}
+
+	// Longer names first, so that a name is not cut short by another that is its prefix
+	slices.SortFunc(assets, func(a, b models.ProblemAsset) int { return len(b.Name) - len(a.Name) })
+
+	links := make([]string, 0, 2*len(assets))
+	for _, a := range assets {
+		url, err := cs.s3.PresignGetObject(ctx, assetKey(problemID, a.ID), a.ContentType, assetLinkExpiry)
+		if err != nil {
+			return "", err
+		}
+		links = append(links, assetLinkPrefix+a.Name, url)
+	}
+
+	return strings.NewReplacer(links...).Replace(description), nil
+}
+
+func (cs *ContestService) ListAssets(ctx context.Context, contestID string, problemID string) ([]models.ProblemAsset, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	return cs.stores.Problems.ListAssets(ctx, problemID)
+}
+
+// CreateAsset uploads an asset of a problem. The name is how the description refers to it
+// and must be unique within the problem.
+func (cs *ContestService) CreateAsset(ctx context.Context, contestID string, problemID string, name string, contentType string, data string, grants models.Grants) (*models.ProblemAsset, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\ ") {
+		return nil, common.InvalidAssetNameError
+	}
+
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemNotShared(ctx, contestID, problemID); err != nil {
+		return nil, err
+	}
+
+	asset := &models.ProblemAsset{
+		ID:          uuid.NewString(),
+		ProblemID:   problemID,
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		CreatedAt:   time.Now().Unix(),
+	}
+
+	if err := cs.s3.PutObject(ctx, assetKey(problemID, asset.ID), data); err != nil {
+		return nil, err
+	}
+
+	if err := cs.stores.Problems.CreateAsset(ctx, asset); err != nil {
+		if delErr := cs.s3.DeleteObject(ctx, assetKey(problemID, asset.ID)); delErr != nil {
+			log.Errorf("failed to remove orphaned asset %s: %v", asset.ID, delErr)
+		}
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+func (cs *ContestService) DeleteAsset(ctx context.Context, contestID string, problemID string, assetID string, grants models.Grants) error {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return err
+	}
+
+	if err := cs.checkProblemNotShared(ctx, contestID, problemID); err != nil {
+		return err
+	}
+
+	if err := cs.stores.Problems.DeleteAsset(ctx, problemID, assetID); err != nil {
+		return err
+	}
+
+	// The row is gone, so a leftover object is unreachable
+	if err := cs.s3.DeleteObject(ctx, assetKey(problemID, assetID)); err != nil {
+		log.Errorf("failed to delete asset %s from s3: %v", assetID, err)
+	}
+
+	return nil
+}
+
+// GetAsset returns an asset of a problem in the user's problem set together with its contents.
+// Visibility of the contest problems must be checked with GetProblemVisibility first.
+func (cs *ContestService) GetAsset(ctx context.Context, contestID string, problemID string, name string, userID string) (*models.ProblemAsset, string, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, "", err
+	}
+
+	if err := cs.CheckProblemInSet(ctx, contestID, userID, problemID); err != nil {
+		return nil, "", common.AssetNotFoundError
+	}
+
+	asset, err := cs.stores.Problems.GetAssetByName(ctx, problemID, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := cs.s3.GetObject(ctx, assetKey(problemID, asset.ID))
+	if err != nil {
+		if errors.Is(err, common.KeyNotFoundError) {
+			return nil, "", common.AssetNotFoundError
+		}
+		return nil, "", err
+	}
+
+	return asset, data, nil
+}
+
+func (cs *ContestService) CreateTestCase(ctx context.Context, contestID string, problemID string, req *dto.CreateTestCaseRequest, grants models.Grants) (*models.TestCase, error) {
+	// Verify the problem belongs to the contest
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return nil, err
+	}
+
+	if err := cs.checkProblemNotShared(ctx, contestID, problemID); err != nil {
+		return nil, err
+	}
+
+	testCase := &models.TestCase{
+		ID:        uuid.NewString(),
+		ProblemID: problemID,
+		IsSample:  req.IsSample,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	if err := cs.s3.PutObject(ctx, testCaseInputKey(problemID, testCase.ID), req.Input); err != nil {
+		return nil, err
+	}
+	if err := cs.s3.PutObject(ctx, testCaseOutputKey(problemID, testCase.ID), req.Output); err != nil {
+		return nil, err
+	}
+
+	if err := cs.stores.Problems.CreateTestCase(ctx, testCase); err != nil {
+		return nil, err
+	}
+
+	return testCase, nil
+}
+
+func (cs *ContestService) ListTestCases(ctx context.Context, contestID string, problemID string) ([]models.TestCase, error) {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return nil, err
+	}
+
+	return cs.stores.Problems.ListTestCases(ctx, problemID)
+}
+
+func (cs *ContestService) DeleteTestCase(ctx context.Context, contestID string, problemID string, testCaseID string, grants models.Grants) error {
+	if _, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID); err != nil {
+		return err
+	}
+
+	if err := cs.checkProblemScope(ctx, problemID, grants); err != nil {
+		return err
+	}
+
+	if err := cs.checkProblemNotShared(ctx, contestID, problemID); err != nil {
+		return err
+	}
+
+	if err := cs.stores.Problems.DeleteTestCase(ctx, problemID, testCaseID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return common.TestCaseNotFoundError
+		}
+		return err
+	}
+
+	// The test case is already gone from the DB, so orphaned files are only logged
+	if err := cs.s3.DeleteObject(ctx, testCaseInputKey(problemID, testCaseID)); err != nil {
+		log.Errorf("failed to delete input of test case %s: %v", testCaseID, err)
+	}
+	if err := cs.s3.DeleteObject(ctx, testCaseOutputKey(problemID, testCaseID)); err != nil {
+		log.Errorf("failed to delete output of test case %s: %v", testCaseID, err)
+	}
+
+	return nil
+}
+
+//Leaderboard related services
+
+// UpdateLeaderboardUser hides or shows a user on the leaderboard and disqualifies or reinstates
+// them, together
+func (cs *ContestService) UpdateLeaderboardUser(ctx context.Context, contestID string, userID string, adminID string, req *dto.UpdateLeaderboardUserRequest) error {
+	var d *models.Disqualification
+	if req.Disqualified != nil {
+		if strings.TrimSpace(req.Reason) == "" {
+			return common.DisqualificationReasonError
+		}
+
+		// The store rejects disqualifying a disqualified user and reinstating one who is not
+		action := models.DisqualifyAction
+		if !*req.Disqualified {
+			action = models.ReinstateAction
+		}
+
+		d = &models.Disqualification{
+			ID:        uuid.NewString(),
+			ContestID: contestID,
+			UserID:    userID,
+			Action:    action,
+			Reason:    req.Reason,
+			Evidence:  req.Evidence,
+			AdminID:   adminID,
+			CreatedAt: time.Now().Unix(),
+		}
+	}
+
+	return cs.stores.Rankings.UpdateLeaderboardUser(ctx, contestID, userID, req.Hidden, d)
+}
+
+func (cs *ContestService) ListDisqualifications(ctx context.Context, contestID string, userID string) ([]models.Disqualification, error) {
+	return cs.stores.Disqualifications.ListDisqualifications(ctx, contestID, userID)
+}
+
+// getDisqualificationStatus returns the user's current disqualification and latest appeal,
+// or nil if the user is not disqualified
+func (cs *ContestService) getDisqualificationStatus(ctx context.Context, contestID string, userID string) (*dto.DisqualificationStatus, error) {
+	latest, err := cs.stores.Disqualifications.GetLatestDisqualification(ctx, contestID, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if latest.Action != models.DisqualifyAction {
+		return nil, nil
+	}
+
+	status := &dto.DisqualificationStatus{
+		Reason:         latest.Reason,
+		DisqualifiedAt: latest.CreatedAt,
+	}
+
+	appeal, err := cs.stores.Disqualifications.GetLatestAppeal(ctx, latest.ID)
+	if err != nil && !errors.Is(err, common.AppealNotFoundError) {
+		return nil, err
+	}
+	status.Appeal = appeal
+
+	return status, nil
+}
+
+func (cs *ContestService) CreateAppeal(ctx context.Context, contestID string, userID string, message string) (*models.DisqualificationAppeal, error) {
+	latest, err := cs.stores.Disqualifications.GetLatestDisqualification(ctx, contestID, userID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, common.UserNotDisqualifiedError
+		}
+		return nil, err
+	}
+
+	if latest.Action != models.DisqualifyAction {
+		return nil, common.UserNotDisqualifiedError
+	}
+
+	appeal := &models.DisqualificationAppeal{
+		ID:                 uuid.NewString(),
+		DisqualificationID: latest.ID,
+		ContestID:          contestID,
+		UserID:             userID,
+		Message:            message,
+		Status:             models.AppealPending,
+		CreatedAt:          time.Now().Unix(),
+	}
+
+	if err := cs.stores.Disqualifications.CreateAppeal(ctx, appeal); err != nil {
+		return nil, err
+	}
+
+	return appeal, nil
+}
+
+func (cs *ContestService) ListAppeals(ctx context.Context, contestID string, status models.AppealStatus, page int) ([]models.DisqualificationAppeal, error) {
+	return cs.stores.Disqualifications.ListAppeals(ctx, contestID, status, page)
+}
+
+// ResolveAppeal accepts or rejects a pending appeal. Accepting an appeal reinstates the user.
+func (cs *ContestService) ResolveAppeal(ctx context.Context, contestID string, appealID string, adminID string, req *dto.ResolveAppealRequest) (*models.DisqualificationAppeal, error) {
+	appeal, err := cs.stores.Disqualifications.GetAppeal(ctx, contestID, appealID)
+	if err != nil {
+		return nil, err
+	}
+
+	if appeal.Status != models.AppealPending {
+		return nil, common.AppealAlreadyResolvedError
+	}
+
+	now := time.Now().Unix()
+	appeal.Status = req.Status
+	appeal.Response = req.Response
+	appeal.ResolvedBy = adminID
+	appeal.ResolvedAt = now
+
+	var reinstatement *models.Disqualification
+	if req.Status == models.AppealAccepted {
+		reinstatement = &models.Disqualification{
+			ID:        uuid.NewString(),
+			ContestID: contestID,
+			UserID:    appeal.UserID,
+			Action:    models.ReinstateAction,
+			Reason:    req.Response,
+			AdminID:   adminID,
+			CreatedAt: now,
+		}
+	}
+
+	if err := cs.stores.Disqualifications.ResolveAppeal(ctx, appeal, reinstatement); err != nil {
+		return nil, err
+	}
+
+	return appeal, nil
+}
+
+func (cs *ContestService) GetProblemVisibility(ctx context.Context, contestID string, userID string) error {
+
+	contest, err := cs.GetContest(ctx, contestID, userID)
+	if err != nil {
+		return err
+	}
+
+	// Practice mode opens the problems of an ended contest to everyone, virtual participation to the participant
+	if (contest.PracticeMode || contest.Virtual != nil) && contest.GetRunningStatus() == models.ContestRunningClosed {
+		return nil
+	}
+
+	if contest.IsRegistered == nil || !*contest.IsRegistered {
+		return common.UserNotRegisteredError
+	}
+
+	if contest.GetRunningStatus() == models.ContestRunningUpcoming {
+		return common.ContestNotRunningError
+	}
+
+	// While a timed contest runs, problems are only visible within the user's attempt
+	if contest.GetRunningStatus() == models.ContestRunningOpen {
+		return cs.CheckAttemptWindow(contest)
+	}
+
+	return nil
+}
+
+// StartAttempt starts the user's personal attempt window in a running timed contest.
+// Starting an attempt again returns the existing attempt.
+func (cs *ContestService) StartAttempt(ctx context.Context, contestID string, userID string) (*dto.ContestAttempt, error) {
+	contest, err := cs.GetContest(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if contest.IsRegistered == nil || !*contest.IsRegistered {
+		return nil, common.UserNotRegisteredError
+	}
+
+	if !contest.IsTimed() {
+		return nil, common.ContestNotTimedError
+	}
+
+	if contest.Attempt != nil {
+		return contest.Attempt, nil
+	}
+
+	if contest.GetRunningStatus() != models.ContestRunningOpen {
+		return nil, common.ContestNotRunningError
+	}
+
+	if err := cs.stores.Contests.StartAttempt(ctx, contestID, userID, time.Now().UnixMilli()); err != nil {
+		return nil, err
+	}
+
+	return cs.getAttempt(ctx, &contest.Contest, userID)
+}
+
+// CheckSubmissionWindow checks that the user may submit to the contest right now. Submissions
+// to an ended contest in practice mode are allowed for everyone and reported as practice.
+// Submissions within a virtual participation are reported as both virtual and practice.
+func (cs *ContestService) CheckSubmissionWindow(contest *dto.GetContestResponse) (practice bool, virtual bool, err error) {
+	switch contest.GetRunningStatus() {
+	case models.ContestRunningUpcoming:
+		return false, false, common.ContestNotRunningError
+	case models.ContestRunningClosed:
+		if contest.Virtual != nil && time.Now().UnixMilli() <= contest.Virtual.Deadline {
+			return true, true, nil
+		}
+		if contest.PracticeMode {
+			return true, false, nil
+		}
+		if contest.Virtual != nil {
+			return false, false, common.AttemptExpiredError
+		}
+		return false, false, common.ContestNotRunningError
+	}
+
+	if contest.IsRegistered == nil || !*contest.IsRegistered {
+		return false, false, common.UserNotRegisteredError
+	}
+
+	return false, false, cs.CheckAttemptWindow(contest)
+}
+
+// CheckAttemptWindow verifies that the user is within their personal attempt window
+// of a timed contest. Contests without a duration are always allowed.
+func (cs *ContestService) CheckAttemptWindow(contest *dto.GetContestResponse) error {
+	if !contest.IsTimed() {
+		return nil
+	}
+
+	if contest.Attempt == nil {
+		return common.AttemptNotStartedError
+	}
+
+	if time.Now().UnixMilli() > contest.Attempt.Deadline {
+		return common.AttemptExpiredError
+	}
+
+	return nil
+}
+
+// StartVirtual starts a virtual participation of the user in an ended contest they did not take part in,
+// lasting the contest's original duration. Starting again returns the existing participation.
+func (cs *ContestService) StartVirtual(ctx context.Context, contestID string, userID string) (*dto.ContestAttempt, error) {
+	contest, err := cs.GetContest(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if contest.Virtual != nil {
+		return contest.Virtual, nil
+	}
+
+	if contest.GetRunningStatus() != models.ContestRunningClosed || contest.TeamMode {
+		return nil, common.VirtualNotAvailableError
+	}
+
+	if contest.IsRegistered != nil && *contest.IsRegistered {
+		return nil, common.AlreadyParticipatedError
+	}
+
+	// Virtual participants must be allowed to register, as for a live run
+	result, err := cs.checkEligibility(ctx, &contest.Contest, userID)
+	if err != nil {
+		log.Errorf("failed to check eligibility of user %s: %v", userID, err)
+		return nil, err
+	}
+
+	if !result.Eligible {
+		return nil, fmt.Errorf("%w: %s", common.NotEligibleError, result.Reason)
+	}
+
+	if err := cs.stores.Contests.StartVirtual(ctx, contestID, userID, time.Now().UnixMilli()); err != nil {
+		return nil, err
+	}
+
+	return cs.getVirtualParticipation(ctx, &contest.Contest, userID)
+}
+
+// GetVirtualLeaderboard ranks the user's virtual participation among the official participants
+// as they stood the same time into the contest
+func (cs *ContestService) GetVirtualLeaderboard(ctx context.Context, contestID string, userID string) ([]models.VirtualRanking, error) {
+	contest, err := cs.GetContest(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if contest.Virtual == nil {
+		return nil, common.VirtualNotStartedError
+	}
+
+	elapsed := min(time.Now().UnixMilli(), contest.Virtual.Deadline) - contest.Virtual.StartedAt
+	return cs.stores.Rankings.GetVirtualLeaderboard(ctx, contestID, userID, elapsed/1000)
+}
+
+// getVirtualParticipation returns the user's virtual participation in a contest, or nil if they have not started one
+func (cs *ContestService) getVirtualParticipation(ctx context.Context, contest *models.Contest, userID string) (*dto.ContestAttempt, error) {
+	startedAt, err := cs.stores.Contests.GetVirtualStart(ctx, contest.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if startedAt == 0 {
+		return nil, nil
+	}
+
+	deadline := startedAt + contest.GetVirtualDuration()
+	return &dto.ContestAttempt{
+		StartedAt:     startedAt,
+		Deadline:      deadline,
+		RemainingTime: max(0, deadline-time.Now().UnixMilli()) / 1000,
+	}, nil
+}
+
+// getAttempt returns the user's attempt in a timed contest, or nil if they have not started
+func (cs *ContestService) getAttempt(ctx context.Context, contest *models.Contest, userID string) (*dto.ContestAttempt, error) {
+	startedAt, err := cs.stores.Contests.GetAttemptStart(ctx, contest.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if startedAt == 0 {
+		return nil, nil
+	}
+
+	deadline := contest.GetAttemptDeadline(startedAt)
+	return &dto.ContestAttempt{
+		StartedAt:     startedAt,
+		Deadline:      deadline,
+		RemainingTime: max(0, deadline-time.Now().UnixMilli()) / 1000,
+	}, nil
+}
+
+// GetContestProblemsList returns the problems of the user's problem set, in the order they are presented to the user
+func (cs *ContestService) GetContestProblemsList(ctx context.Context, contestID string, userID string) ([]dto.ProblemOverview, error) {
+	problems, err := cs.stores.Problems.GetProblemList(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := cs.getProblemSet(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return problems, nil
+	}
+
+	byID := make(map[string]dto.ProblemOverview, len(problems))
+	for _, p := range problems {
+		byID[p.ID] = p
+	}
+
+	ordered := make([]dto.ProblemOverview, 0, len(set))
+	for _, e := range set {
+		if p, ok := byID[e.ProblemID]; ok {
+			p.Position = e.Position
+			ordered = append(ordered, p)
+		}
+	}
+
+	return ordered, nil
+}
+
+// GetContestProblem returns a problem of the user's problem set, with MCQ options in the order they are presented to the user
+func (cs *ContestService) GetContestProblem(ctx context.Context, contestID string, problemID string, userID string) (*dto.GetProblemStatementResponse, error) {
+	problem, err := cs.stores.Problems.GetProblem(ctx, problemID, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := cs.getProblemSet(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	problem.Description, err = cs.rewriteAssetLinks(ctx, problem.Description, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry *models.ProblemSetEntry
+	if set != nil {
+		i := slices.IndexFunc(set, func(e models.ProblemSetEntry) bool { return e.ProblemID == problemID })
+		if i < 0 {
+			return nil, common.ProblemNotFoundError
+		}
+		entry = &set[i]
+	}
+
+	if problem.Type == models.MCQ {
+		problem.Options, err = cs.stores.Problems.ListOptions(ctx, problemID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Weights only matter for correct options, showing them would hint at the answer
+		for i := range problem.Options {
+			problem.Options[i].Weight = 0
+		}
+
+		if entry != nil && len(entry.OptionOrder) > 0 {
+			problem.Options = orderOptions(problem.Options, entry.OptionOrder)
+		}
+	}
+
+	return problem, nil
+}
+
+// orderOptions arranges options in the given order of IDs. Options missing from the order,
+// because they were added after it was drawn, keep their relative order at the end.
+func orderOptions(options []models.ProblemOption, order []int) []models.ProblemOption {
+	ordered := make([]models.ProblemOption, 0, len(options))
+	for _, id := range order {
+		if i := slices.IndexFunc(options, func(o models.ProblemOption) bool { return o.ID == id }); i >= 0 {
+			ordered = append(ordered, options[i])
+		}
+	}
+	for _, o := range options {
+		if !slices.Contains(order, o.ID) {
+			ordered = append(ordered, o)
+		}
+	}
+	return ordered
+}
+
+// CheckProblemInSet returns ErrNotFound if the problem was not drawn for the user
+func (cs *ContestService) CheckProblemInSet(ctx context.Context, contestID string, userID string, problemID string) error {
+	set, err := cs.getProblemSet(ctx, contestID, userID)
+	if err != nil {
+		return err
+	}
+
+	if set != nil && !slices.ContainsFunc(set, func(e models.ProblemSetEntry) bool { return e.ProblemID == problemID }) {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// getProblemSet returns the problems drawn for the user in presentation order, drawing and
+// storing them on first use so they stay fixed. Problems every user gets that were added after
+// the draw are appended to the set. Returns nil for contests without shuffling or pools, where
+// everyone gets every problem.
+func (cs *ContestService) getProblemSet(ctx context.Context, contestID string, userID string) ([]models.ProblemSetEntry, error) {
+	contest, err := cs.stores.Contests.GetContest(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	pools, err := cs.stores.ProblemSets.ListPools(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !contest.ShuffleProblems && !contest.ShuffleOptions && len(pools) == 0 {
+		return nil, nil
+	}
+
+	set, err := cs.stores.ProblemSets.GetProblemSet(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	problems, err := cs.stores.Problems.ListProblems(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(set) > 0 {
+		missing := missingProblems(&contest.Contest, userID, set, problems, pools)
+		if len(missing) == 0 {
+			return set, nil
+		}
+
+		if err := cs.stores.ProblemSets.AddProblemSetEntries(ctx, contestID, userID, missing); err != nil {
+			return nil, err
+		}
+		return cs.stores.ProblemSets.GetProblemSet(ctx, contestID, userID)
+	}
+
+	set = drawProblemSet(&contest.Contest, userID, problems, pools)
+	if err := cs.stores.ProblemSets.CreateProblemSet(ctx, contestID, userID, set); err != nil {
+		return nil, err
+	}
+
+	// Read back in case a concurrent request stored its draw first
+	return cs.stores.ProblemSets.GetProblemSet(ctx, contestID, userID)
+}
+
+// drawProblemSet picks the problems for a user and the order to present them in. The
+// draw is seeded by the contest and user so it is the same every time it is made.
+// Problems without a pool, or with a tag that has no pool configured, are always included.
+func drawProblemSet(contest *models.Contest, userID string, problems []models.Problem, pools []models.ContestPool) []models.ProblemSetEntry {
+	h := fnv.New64a()
+	h.Write([]byte(contest.ID + "/" + userID))
+	rng := rand.New(rand.NewPCG(h.Sum64(), 0))
+
+	byPool := make(map[string][]models.Problem)
+	for _, pool := range pools {
+		byPool[pool.Tag] = nil
+	}
+
+	var selected []models.Problem
+	for _, p := range problems {
+		if _, ok := byPool[p.Pool]; ok && p.Pool != "" {
+			byPool[p.Pool] = append(byPool[p.Pool], p)
+		} else {
+			selected = append(selected, p)
+		}
+	}
+
+	for _, pool := range pools {
+		candidates := byPool[pool.Tag]
+		rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+		selected = append(selected, candidates[:min(pool.Draw, len(candidates))]...)
+	}
+
+	if contest.ShuffleProblems {
+		rng.Shuffle(len(selected), func(i, j int) { selected[i], selected[j] = selected[j], selected[i] })
+	}
+
+	set := make([]models.ProblemSetEntry, 0, len(selected))
+	for i, p := range selected {
+		e := models.ProblemSetEntry{
+			ContestID: contest.ID,
+			UserID:    userID,
+			ProblemID: p.ID,
+			Position:  i + 1,
+		}
+
+		if contest.ShuffleOptions && len(p.Options) > 0 {
+			e.OptionOrder = shuffleOptions(p, rng)
+		}
+
+		set = append(set, e)
+	}
+
+	return set
+}
+
+// missingProblems returns entries for the problems outside of any pool that are not in the
+// user's set, such as problems added to the contest or taken out of a pool after the set was
+// drawn. Their options are shuffled with a seed of their own, so the draw stays repeatable.
+func missingProblems(contest *models.Contest, userID string, set []models.ProblemSetEntry, problems []models.Problem, pools []models.ContestPool) []models.ProblemSetEntry {
+	pooled := make(map[string]bool, len(pools))
+	for _, pool := range pools {
+		pooled[pool.Tag] = true
+	}
+
+	var missing []models.ProblemSetEntry
+	for _, p := range problems {
+		if p.Pool != "" && pooled[p.Pool] {
+			continue
+		}
+		if slices.ContainsFunc(set, func(e models.ProblemSetEntry) bool { return e.ProblemID == p.ID }) {
+			continue
+		}
+
+		e := models.ProblemSetEntry{
+			ContestID: contest.ID,
+			UserID:    userID,
+			ProblemID: p.ID,
+		}
+
+		if contest.ShuffleOptions && len(p.Options) > 0 {
+			h := fnv.New64a()
+			h.Write([]byte(contest.ID + "/" + userID + "/" + p.ID))
+			e.OptionOrder = shuffleOptions(p, rand.New(rand.NewPCG(h.Sum64(), 0)))
+		}
+
+		missing = append(missing, e)
+	}
+
+	return missing
+}
+
+// shuffleOptions returns the option IDs of a problem in a random order
+func shuffleOptions(p models.Problem, rng *rand.Rand) []int {
+	order := make([]int, 0, len(p.Options))
+	for _, o := range p.Options {
+		order = append(order, o.ID)
+	}
+	rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	return order
+}
+
+// GetUserProblemSet returns the problems drawn for a user, with original problem and option IDs, for admin review
+func (cs *ContestService) GetUserProblemSet(ctx context.Context, contestID string, userID string) ([]models.ProblemSetEntry, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	return cs.stores.ProblemSets.GetProblemSet(ctx, contestID, userID)
+}
+
+func (cs *ContestService) ListPools(ctx context.Context, contestID string) ([]models.ContestPool, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	return cs.stores.ProblemSets.ListPools(ctx, contestID)
+}
+
+func (cs *ContestService) UpsertPool(ctx context.Context, contestID string, tag string, draw int) (*models.ContestPool, error) {
+	if _, err := cs.stores.Contests.GetContest(ctx, contestID); err != nil {
+		return nil, err
+	}
+
+	pool := &models.ContestPool{
+		ContestID: contestID,
+		Tag:       tag,
+		Draw:      draw,
+	}
+
+	if err := cs.stores.ProblemSets.UpsertPool(ctx, pool); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+func (cs *ContestService) DeletePool(ctx context.Context, contestID string, tag string) error {
+	return cs.stores.ProblemSets.DeletePool(ctx, contestID, tag)
+}
+
+// GetProblemDetails returns a problem of a contest with its answer, for admins
+func (cs *ContestService) GetProblemDetails(ctx context.Context, contestID string, problemID string) (*models.Problem, error) {
+	return cs.stores.Problems.GetProblemDetails(ctx, problemID, contestID)
+}
+
+// GetRanking returns the user's ranking in a contest, or nil if they have none
+func (cs *ContestService) GetRanking(ctx context.Context, contestID string, userID string) (*models.Ranking, error) {
+	return cs.stores.Rankings.GetRanking(ctx, contestID, userID)
+}
+
+// GetContestDetails returns a contest regardless of its status, for admins
+func (cs *ContestService) GetContestDetails(ctx context.Context, contestID string) (*dto.GetContestResponse, error) {
+	return cs.stores.Contests.GetContest(ctx, contestID)
+}
+
+// GetContest returns a contest visible to the public, with user-specific details if userID is set.
+// Draft contests are reported as not found.
+func (cs *ContestService) GetContest(ctx context.Context, contestID string, userID string) (*dto.GetContestResponse, error) {
+	contest_response, err := cs.stores.Contests.GetContest(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if contest_response.Status == models.ContestDraft {
+		return nil, common.ContestNotFoundError
+	}
+
 	if userID == "" {
 		return contest_response, nil
 	}
@@ -156,5 +2184,43 @@ func (cs *ContestService) GetContest(ctx context.Context, contestID string, user
 	}
 
 	contest_response.IsRegistered = &r
+
+	// Tell users who could still register whether they may
+	if !r && contest_response.GetRegistrationStatus() != models.ContestRegistrationClosed {
+		contest_response.Eligibility, err = cs.checkEligibility(ctx, &contest_response.Contest, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !r && contest_response.MaxParticipants > 0 {
+		position, err := cs.stores.Contests.GetWaitlistPosition(ctx, contestID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if position > 0 {
+			contest_response.WaitlistPosition = &position
+		}
+	}
+
+	contest_response.Disqualification, err = cs.getDisqualificationStatus(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r && contest_response.IsTimed() {
+		contest_response.Attempt, err = cs.getAttempt(ctx, &contest_response.Contest, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !r && contest_response.GetRunningStatus() == models.ContestRunningClosed {
+		contest_response.Virtual, err = cs.getVirtualParticipation(ctx, &contest_response.Contest, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return contest_response, nil
 }
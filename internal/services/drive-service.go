@@ -0,0 +1,111 @@
+package services
+
+import (
+	"app/internal/models"
+	"app/internal/models/dto"
+	"app/internal/stores"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DriveService struct {
+	stores *stores.Storage
+}
+
+func NewDriveService(stores *stores.Storage) *DriveService {
+	return &DriveService{
+		stores: stores,
+	}
+}
+
+func (ds *DriveService) ListDrives(ctx context.Context, page int) ([]models.RecruitmentDrive, error) {
+	return ds.stores.Drives.ListDrives(ctx, page)
+}
+
+func (ds *DriveService) GetDrive(ctx context.Context, driveID string) (*models.RecruitmentDrive, error) {
+	return ds.stores.Drives.GetDrive(ctx, driveID)
+}
+
+func (ds *DriveService) CreateDrive(ctx context.Context, adminID string, req *dto.UpsertDriveRequest) (*models.RecruitmentDrive, error) {
+	drive := &models.RecruitmentDrive{
+		ID:          uuid.NewString(),
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedBy:   adminID,
+		CreatedAt:   time.Now().Unix(),
+		Rounds:      []models.DriveRound{},
+	}
+
+	if err := ds.stores.Drives.CreateDrive(ctx, drive); err != nil {
+		return nil, err
+	}
+	return drive, nil
+}
+
+func (ds *DriveService) UpdateDrive(ctx context.Context, driveID string, req *dto.UpsertDriveRequest) (*models.RecruitmentDrive, error) {
+	drive := &models.RecruitmentDrive{
+		ID:          driveID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if err := ds.stores.Drives.UpdateDrive(ctx, drive); err != nil {
+		return nil, err
+	}
+	return ds.stores.Drives.GetDrive(ctx, driveID)
+}
+
+func (ds *DriveService) DeleteDrive(ctx context.Context, driveID string) error {
+	return ds.stores.Drives.DeleteDrive(ctx, driveID)
+}
+
+// SetRounds replaces the rounds of a drive. A contest can be a round of one drive only.
+func (ds *DriveService) SetRounds(ctx context.Context, driveID string, contestIDs []string) (*models.RecruitmentDrive, error) {
+	if err := ds.stores.Drives.SetRounds(ctx, driveID, contestIDs); err != nil {
+		return nil, err
+	}
+	return ds.stores.Drives.GetDrive(ctx, driveID)
+}
+
+// GetFunnel counts the candidates registered, attempting and shortlisted in each round
+func (ds *DriveService) GetFunnel(ctx context.Context, driveID string) ([]models.DriveFunnelRound, error) {
+	if _, err := ds.stores.Drives.GetDrive(ctx, driveID); err != nil {
+		return nil, err
+	}
+
+	return ds.stores.Drives.GetFunnel(ctx, driveID)
+}
+
+// GetProgress returns the drive with the user's progress through its published rounds
+func (ds *DriveService) GetProgress(ctx context.Context, driveID string, userID string) (*dto.DriveProgressResponse, error) {
+	drive, err := ds.stores.Drives.GetDrive(ctx, driveID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := ds.stores.Drives.GetProgress(ctx, driveID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rounds still being drafted are hidden from candidates
+	response := &dto.DriveProgressResponse{
+		RecruitmentDrive: *drive,
+		Progress:         make([]models.DriveRoundProgress, 0, len(progress)),
+	}
+	response.Rounds = make([]models.DriveRound, 0, len(drive.Rounds))
+	for _, round := range drive.Rounds {
+		if round.Status != models.ContestDraft {
+			response.Rounds = append(response.Rounds, round)
+		}
+	}
+	for _, p := range progress {
+		if p.Status != models.ContestDraft {
+			response.Progress = append(response.Progress, p)
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"app/internal/models/dto"
+	"app/internal/stores"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InterviewService struct {
+	stores *stores.Storage
+}
+
+func NewInterviewService(stores *stores.Storage) *InterviewService {
+	return &InterviewService{
+		stores: stores,
+	}
+}
+
+// checkCandidate makes sure the user was shortlisted in the final round of the drive
+func (is *InterviewService) checkCandidate(ctx context.Context, driveID string, userID string) error {
+	if _, err := is.stores.Drives.GetDrive(ctx, driveID); err != nil {
+		return err
+	}
+
+	progress, err := is.stores.Drives.GetProgress(ctx, driveID, userID)
+	if err != nil {
+		return err
+	}
+
+	if len(progress) == 0 || !progress[len(progress)-1].Shortlisted {
+		return common.CandidateNotShortlistedError
+	}
+	return nil
+}
+
+func (is *InterviewService) checkInterviewers(ctx context.Context, adminIDs []string) error {
+	for _, adminID := range adminIDs {
+		grants, err := is.stores.Admins.ListGrants(ctx, adminID)
+		if err != nil {
+			return err
+		}
+		if !grants.Can(models.PermInterviewsFeedback, "") {
+			return common.InterviewerNotAdminError
+		}
+	}
+	return nil
+}
+
+func (is *InterviewService) ListInterviews(ctx context.Context, driveID string) ([]models.Interview, error) {
+	if _, err := is.stores.Drives.GetDrive(ctx, driveID); err != nil {
+		return nil, err
+	}
+
+	return is.stores.Interviews.ListInterviews(ctx, driveID, "")
+}
+
+// ListCandidateInterviews lists the user's interview slots in a drive, without the interviewers
+func (is *InterviewService) ListCandidateInterviews(ctx context.Context, driveID string, userID string) ([]dto.CandidateInterview, error) {
+	if _, err := is.stores.Drives.GetDrive(ctx, driveID); err != nil {
+		return nil, err
+	}
+
+	interviews, err := is.stores.Interviews.ListInterviews(ctx, driveID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make([]dto.CandidateInterview, len(interviews))
+	for i, interview := range interviews {
+		slots[i] = dto.CandidateInterview{
+			ID:          interview.ID,
+			Title:       interview.Title,
+			ScheduledAt: interview.ScheduledAt,
+			Duration:    interview.Duration,
+			Location:    interview.Location,
+		}
+	}
+	return slots, nil
+}
+
+// CreateInterview schedules an interview of a candidate shortlisted in the final round of the drive
+func (is *InterviewService) CreateInterview(ctx context.Context, driveID string, adminID string, req *dto.UpsertInterviewRequest) (*models.Interview, error) {
+	if err := is.checkCandidate(ctx, driveID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := is.checkInterviewers(ctx, req.Interviewers); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	interview := &models.Interview{
+		ID:           uuid.NewString(),
+		DriveID:      driveID,
+		UserID:       req.UserID,
+		Title:        req.Title,
+		ScheduledAt:  req.ScheduledAt,
+		Duration:     req.Duration,
+		Location:     req.Location,
+		Interviewers: req.Interviewers,
+		CreatedBy:    adminID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := is.stores.Interviews.CreateInterview(ctx, interview); err != nil {
+		return nil, err
+	}
+	return interview, nil
+}
+
+func (is *InterviewService) UpdateInterview(ctx context.Context, driveID string, interviewID string, req *dto.UpsertInterviewRequest) (*models.Interview, error) {
+	interview, err := is.stores.Interviews.GetInterview(ctx, driveID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserID != interview.UserID {
+		if err := is.checkCandidate(ctx, driveID, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := is.checkInterviewers(ctx, req.Interviewers); err != nil {
+		return nil, err
+	}
+
+	interview.UserID = req.UserID
+	interview.Title = req.Title
+	interview.ScheduledAt = req.ScheduledAt
+	interview.Duration = req.Duration
+	interview.Location = req.Location
+	interview.Interviewers = req.Interviewers
+	interview.UpdatedAt = time.Now().Unix()
+
+	if err := is.stores.Interviews.UpdateInterview(ctx, interview); err != nil {
+		return nil, err
+	}
+	return interview, nil
+}
+
+func (is *InterviewService) DeleteInterview(ctx context.Context, driveID string, interviewID string) error {
+	return is.stores.Interviews.DeleteInterview(ctx, driveID, interviewID)
+}
+
+func (is *InterviewService) ListFeedback(ctx context.Context, driveID string, interviewID string) ([]models.InterviewFeedback, error) {
+	if _, err := is.stores.Interviews.GetInterview(ctx, driveID, interviewID); err != nil {
+		return nil, err
+	}
+
+	return is.stores.Interviews.ListFeedback(ctx, interviewID)
+}
+
+// SubmitFeedback records the feedback form of an interviewer assigned to the interview
+func (is *InterviewService) SubmitFeedback(ctx context.Context, driveID string, interviewID string, adminID string, req *dto.SubmitFeedbackRequest) (*models.InterviewFeedback, error) {
+	interview, err := is.stores.Interviews.GetInterview(ctx, driveID, interviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(interview.Interviewers, adminID) {
+		return nil, common.InterviewerNotAssignedError
+	}
+
+	now := time.Now().Unix()
+	feedback := &models.InterviewFeedback{
+		InterviewID:    interviewID,
+		InterviewerID:  adminID,
+		ProblemSolving: req.ProblemSolving,
+		Coding:         req.Coding,
+		Communication:  req.Communication,
+		Comments:       req.Comments,
+		SubmittedAt:    now,
+		UpdatedAt:      now,
+	}
+
+	if err := is.stores.Interviews.UpsertFeedback(ctx, feedback); err != nil {
+		return nil, err
+	}
+	return feedback, nil
+}
+
+func (is *InterviewService) ListDecisions(ctx context.Context, driveID string) ([]models.CandidateDecision, error) {
+	if _, err := is.stores.Drives.GetDrive(ctx, driveID); err != nil {
+		return nil, err
+	}
+
+	return is.stores.Interviews.ListDecisions(ctx, driveID)
+}
+
+// SetDecision records whether a shortlisted candidate is selected, rejected or on hold
+func (is *InterviewService) SetDecision(ctx context.Context, driveID string, userID string, adminID string, req *dto.SetDecisionRequest) (*models.CandidateDecision, error) {
+	if err := is.checkCandidate(ctx, driveID, userID); err != nil {
+		return nil, err
+	}
+
+	decision := &models.CandidateDecision{
+		DriveID:   driveID,
+		UserID:    userID,
+		Decision:  req.Decision,
+		Notes:     req.Notes,
+		DecidedBy: adminID,
+		DecidedAt: time.Now().Unix(),
+	}
+
+	if err := is.stores.Interviews.SetDecision(ctx, decision); err != nil {
+		return nil, err
+	}
+	return decision, nil
+}
@@ -1,11 +1,13 @@
 package services
 
 import (
+	"app/internal/common"
 	"app/internal/models"
 	"app/internal/models/dto"
 	"app/internal/s3"
 	"app/internal/stores"
 	"context"
+	"errors"
 )
 
 type SubmissionService struct {
@@ -47,7 +49,9 @@ func (ss *SubmissionService) ListUserSubmissionsByProblemID(ctx context.Context,
 	return sub, nil
 }
 
-func (ss *SubmissionService) CreateSubmission(ctx context.Context, userID string, submissionType models.SubmissionType, req *dto.SubmitSubmissionRequest) (string, error) {
+// CreateSubmission records and grades a submission. Practice submissions, virtual ones included,
+// are graded like any other but never change the user's ranking.
+func (ss *SubmissionService) CreateSubmission(ctx context.Context, userID string, submissionType models.SubmissionType, practice bool, virtual bool, req *dto.SubmitSubmissionRequest) (string, error) {
 	sub := &models.Submission{
 		UserID:    userID,
 		ContestID: req.ContestID,
@@ -56,7 +60,23 @@ func (ss *SubmissionService) CreateSubmission(ctx context.Context, userID string
 		Status:    models.Pending,
 		Language:  req.Language,
 		Option:    req.Option,
+		Practice:  practice,
+		Virtual:   virtual,
 	}
+
+	// In team contests submissions count for the submitting user's team
+	teamID, err := ss.stores.Teams.GetRegisteredTeamID(ctx, req.ContestID, userID)
+	if err != nil {
+		return "", err
+	}
+	sub.TeamID = teamID
+
+	if submissionType == models.MCQ {
+		if err := ss.gradeMCQ(ctx, sub); err != nil {
+			return "", err
+		}
+	}
+
 	submissionID, err := ss.stores.Submissions.CreateSubmission(ctx, sub)
 	if err != nil {
 		return "", err
@@ -69,3 +89,34 @@ func (ss *SubmissionService) CreateSubmission(ctx context.Context, userID string
 	}
 	return submissionID, nil
 }
+
+// gradeMCQ checks that the selected options exist on the problem, and that exactly
+// one is selected for single-choice problems, then grades the submission. Problems
+// without structured options keep their options in the description and are left pending.
+func (ss *SubmissionService) gradeMCQ(ctx context.Context, sub *models.Submission) error {
+	problem, err := ss.stores.Problems.GetProblemDetails(ctx, sub.ProblemID, sub.ContestID)
+	if err != nil {
+		if errors.Is(err, common.ContestNotFoundError) {
+			return common.ErrNotFound
+		}
+		return err
+	}
+
+	if len(problem.Options) == 0 {
+		return nil
+	}
+
+	if len(sub.Option) == 0 || (!problem.MultipleChoice && len(sub.Option) > 1) {
+		return common.InvalidOptionError
+	}
+
+	if !models.ContainsOptions(problem.Options, sub.Option) {
+		return common.InvalidOptionError
+	}
+
+	status, score := problem.GradeMCQ(sub.Option)
+	sub.Status = status
+	sub.Score = &score
+
+	return nil
+}
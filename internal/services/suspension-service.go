@@ -0,0 +1,94 @@
+package services
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"app/internal/models/dto"
+	"app/internal/stores"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SuspensionService struct {
+	stores *stores.Storage
+}
+
+func NewSuspensionService(stores *stores.Storage) *SuspensionService {
+	return &SuspensionService{
+		stores: stores,
+	}
+}
+
+// GetActiveSuspension returns what keeps the user from taking part in the contest, or nil if nothing does.
+// A banned account counts as suspended everywhere, indefinitely.
+func (ss *SuspensionService) GetActiveSuspension(ctx context.Context, userID string, contestID string) (*models.Suspension, error) {
+	user, err := ss.stores.Users.GetAdminUser(ctx, userID)
+	if err != nil && err != common.UserNotFoundError {
+		return nil, err
+	}
+
+	if user != nil && user.BannedAt != 0 {
+		return &models.Suspension{
+			UserID:    userID,
+			Reason:    user.BanReason,
+			CreatedBy: user.BannedBy,
+			CreatedAt: user.BannedAt,
+		}, nil
+	}
+
+	return ss.stores.Suspensions.GetActiveSuspension(ctx, userID, contestID, time.Now().UnixMilli())
+}
+
+func (ss *SuspensionService) ListSuspensions(ctx context.Context, req *dto.ListSuspensionsRequest) ([]models.Suspension, error) {
+	return ss.stores.Suspensions.ListSuspensions(ctx, req)
+}
+
+// Suspend suspends a user globally or from a single contest, until expires_at or until lifted
+func (ss *SuspensionService) Suspend(ctx context.Context, userID string, adminID string, req *dto.CreateSuspensionRequest) (*models.Suspension, error) {
+	if req.ExpiresAt != 0 && req.ExpiresAt <= time.Now().UnixMilli() {
+		return nil, common.InvalidSuspensionExpiryError
+	}
+
+	if _, err := ss.stores.Users.GetAdminUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if req.ContestID != "" {
+		if _, err := ss.stores.Contests.GetContest(ctx, req.ContestID); err != nil {
+			return nil, err
+		}
+	}
+
+	suspension := &models.Suspension{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		ContestID: req.ContestID,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: adminID,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	if err := ss.stores.Suspensions.CreateSuspension(ctx, suspension); err != nil {
+		return nil, err
+	}
+
+	return suspension, nil
+}
+
+func (ss *SuspensionService) Lift(ctx context.Context, suspensionID string, adminID string, reason string) (*models.Suspension, error) {
+	suspension := &models.Suspension{
+		ID:         suspensionID,
+		LiftedAt:   time.Now().Unix(),
+		LiftedBy:   adminID,
+		LiftReason: reason,
+	}
+
+	if err := ss.stores.Suspensions.LiftSuspension(ctx, suspension); err != nil {
+		return nil, err
+	}
+
+	return suspension, nil
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"app/internal/stores"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/log"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+	"go.uber.org/fx"
+)
+
+// teamLeaderboardRefreshInterval is how often the team leaderboard view is recomputed
+const teamLeaderboardRefreshInterval = time.Minute
+
+type TeamService struct {
+	stores            *stores.Storage
+	contestService    *ContestService
+	suspensionService *SuspensionService
+}
+
+// NewTeamService creates the team service and keeps the team leaderboard refreshed while the app runs
+func NewTeamService(lc fx.Lifecycle, stores *stores.Storage, contestService *ContestService, suspensionService *SuspensionService) *TeamService {
+	ts := &TeamService{
+		stores:            stores,
+		contestService:    contestService,
+		suspensionService: suspensionService,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go ts.refreshLoop(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+
+	return ts
+}
+
+func (ts *TeamService) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(teamLeaderboardRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := ts.stores.Rankings.RefreshTeamLeaderboard(ctx); err != nil {
+			log.Errorf("failed to refresh team leaderboard: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetLeaderboard returns a page of the team leaderboard of a published team contest
+func (ts *TeamService) GetLeaderboard(ctx context.Context, contestID string, page int) ([]models.TeamRanking, error) {
+	contest, err := ts.contestService.GetContest(ctx, contestID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if !contest.TeamMode {
+		return nil, common.ContestNotTeamModeError
+	}
+
+	return ts.stores.Rankings.GetTeamLeaderboard(ctx, contestID, page)
+}
+
+// getTeamContest returns a team contest that is open for registration, teams only change while it is
+func (ts *TeamService) getTeamContest(ctx context.Context, contestID string, userID string) (*models.Contest, error) {
+	contest, err := ts.contestService.GetContest(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !contest.TeamMode {
+		return nil, common.ContestNotTeamModeError
+	}
+
+	if contest.GetRegistrationStatus() != models.ContestRegistrationOpen {
+		return nil, common.ContestRegistrationClosedError
+	}
+
+	return &contest.Contest, nil
+}
+
+func (ts *TeamService) GetTeam(ctx context.Context, contestID string, userID string) (*models.Team, error) {
+	return ts.stores.Teams.GetUserTeam(ctx, contestID, userID)
+}
+
+// CreateTeam creates a team led by the user, who shares its invite code with the other members
+func (ts *TeamService) CreateTeam(ctx context.Context, contestID string, userID string, name string) (*models.Team, error) {
+	if _, err := ts.getTeamContest(ctx, contestID, userID); err != nil {
+		return nil, err
+	}
+
+	code, err := gonanoid.Generate(inviteCodeAlphabet, 8)
+	if err != nil {
+		log.Errorf("failed to generate team invite code: %v", err)
+		return nil, err
+	}
+
+	team := &models.Team{
+		ID:         uuid.NewString(),
+		ContestID:  contestID,
+		Name:       strings.TrimSpace(name),
+		InviteCode: code,
+		LeaderID:   userID,
+		CreatedAt:  time.Now().Unix(),
+	}
+
+	if err := ts.stores.Teams.CreateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (ts *TeamService) JoinTeam(ctx context.Context, contestID string, userID string, inviteCode string) (*models.Team, error) {
+	contest, err := ts.getTeamContest(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(inviteCode))
+	if err := ts.stores.Teams.JoinTeam(ctx, contestID, code, userID, contest.MaxTeamSize, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+
+	return ts.stores.Teams.GetUserTeam(ctx, contestID, userID)
+}
+
+// LeaveTeam removes the user from their team, disbanding it if they lead it
+func (ts *TeamService) LeaveTeam(ctx context.Context, contestID string, userID string) error {
+	if _, err := ts.getTeamContest(ctx, contestID, userID); err != nil {
+		return err
+	}
+
+	return ts.stores.Teams.LeaveTeam(ctx, contestID, userID)
+}
+
+// RegisterTeam registers the user's team once it has an allowed number of members who are all eligible
+func (ts *TeamService) RegisterTeam(ctx context.Context, contestID string, userID string) (*models.Team, error) {
+	contest, err := ts.getTeamContest(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := ts.stores.Teams.GetUserTeam(ctx, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.LeaderID != userID {
+		return nil, common.NotTeamLeaderError
+	}
+
+	if len(team.Members) < contest.MinTeamSize || len(team.Members) > contest.MaxTeamSize {
+		return nil, fmt.Errorf("%w: teams need %d to %d members", common.TeamSizeError, contest.MinTeamSize, contest.MaxTeamSize)
+	}
+
+	for _, member := range team.Members {
+		result, err := ts.contestService.checkEligibility(ctx, contest, member.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Eligible {
+			return nil, fmt.Errorf("%w: member %s: %s", common.NotEligibleError, member.UserID, result.Reason)
+		}
+
+		// The leader is checked by the route, the other members are not
+		suspension, err := ts.suspensionService.GetActiveSuspension(ctx, member.UserID, contestID)
+		if err != nil {
+			return nil, err
+		}
+		if suspension != nil {
+			return nil, fmt.Errorf("%w: member %s", common.AccountSuspendedError, member.UserID)
+		}
+	}
+
+	if err := ts.stores.Teams.RegisterTeam(ctx, contestID, team.ID, time.Now().Unix()); err != nil {
+		return nil, err
+	}
+
+	return ts.stores.Teams.GetUserTeam(ctx, contestID, userID)
+}
+
+func (ts *TeamService) UnregisterTeam(ctx context.Context, contestID string, userID string) error {
+	if _, err := ts.getTeamContest(ctx, contestID, userID); err != nil {
+		return err
+	}
+
+	team, err := ts.stores.Teams.GetUserTeam(ctx, contestID, userID)
+	if err != nil {
+		return err
+	}
+
+	if team.LeaderID != userID {
+		return common.NotTeamLeaderError
+	}
+
+	return ts.stores.Teams.UnregisterTeam(ctx, contestID, team.ID)
+}
@@ -1,12 +1,14 @@
 package services
 
 import (
+	"app/internal/common"
 	"app/internal/models"
 	"app/internal/models/dto"
 	"app/internal/stores"
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"firebase.google.com/go/v4/auth"
 )
@@ -37,3 +39,128 @@ func (us *UserService) GetUserProfile(ctx context.Context, userID string) (*mode
 func (us *UserService) UpdateUserProfile(ctx context.Context, userID string, req *dto.UpdateUserProfileRequest) error {
 	return us.stores.Users.UpdateUserProfile(ctx, userID, req)
 }
+
+func (us *UserService) SearchUsers(ctx context.Context, req *dto.AdminListUsersRequest) ([]dto.AdminUser, error) {
+	return us.stores.Users.SearchUsers(ctx, req)
+}
+
+func (us *UserService) GetAdminUser(ctx context.Context, userID string) (*dto.AdminUser, error) {
+	return us.stores.Users.GetAdminUser(ctx, userID)
+}
+
+// GetUserDetails returns a user with their contest registrations and a page of their submissions
+func (us *UserService) GetUserDetails(ctx context.Context, userID string, page int) (*dto.AdminUserDetails, error) {
+	user, err := us.stores.Users.GetAdminUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	registrations, err := us.stores.Users.ListUserRegistrations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := us.stores.Submissions.ListUserSubmissions(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminUserDetails{
+		AdminUser:     *user,
+		Registrations: registrations,
+		Submissions:   submissions,
+	}, nil
+}
+
+func (us *UserService) AdminUpdateUser(ctx context.Context, userID string, req *dto.AdminUpdateUserRequest, grants models.Grants) error {
+	if err := us.checkOutranks(ctx, grants, userID); err != nil {
+		return err
+	}
+
+	return us.stores.Users.AdminUpdateUser(ctx, userID, req)
+}
+
+// BanUser bans a user and disables their Firebase account. Revoking their refresh tokens
+// signs them out everywhere once their current ID token expires. The account is disabled
+// first, so that a ban is never recorded for a user who can still sign in.
+func (us *UserService) BanUser(ctx context.Context, userID string, adminID string, reason string, grants models.Grants) error {
+	if userID == adminID {
+		return common.CannotBanSelfError
+	}
+
+	if _, err := us.stores.Users.GetAdminUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := us.checkOutranks(ctx, grants, userID); err != nil {
+		return err
+	}
+
+	if err := us.setAccountDisabled(ctx, userID, true); err != nil {
+		return err
+	}
+
+	if err := us.stores.Users.SetBan(ctx, userID, time.Now().Unix(), reason, adminID); err != nil {
+		if enableErr := us.setAccountDisabled(ctx, userID, false); enableErr != nil {
+			log.Printf("user-service: error enabling user %s again: %v", userID, enableErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UnbanUser lifts a user's ban and enables their Firebase account again
+func (us *UserService) UnbanUser(ctx context.Context, userID string, grants models.Grants) error {
+	if _, err := us.stores.Users.GetAdminUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := us.checkOutranks(ctx, grants, userID); err != nil {
+		return err
+	}
+
+	if err := us.setAccountDisabled(ctx, userID, false); err != nil {
+		return err
+	}
+
+	if err := us.stores.Users.SetBan(ctx, userID, 0, "", ""); err != nil {
+		if disableErr := us.setAccountDisabled(ctx, userID, true); disableErr != nil {
+			log.Printf("user-service: error disabling user %s again: %v", userID, disableErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkOutranks checks that the admin's highest role ranks above every role of the user
+func (us *UserService) checkOutranks(ctx context.Context, grants models.Grants, userID string) error {
+	userGrants, err := us.stores.Admins.ListGrants(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if userGrants.Rank() >= grants.Rank() {
+		return common.RoleNotHigherError
+	}
+	return nil
+}
+
+func (us *UserService) setAccountDisabled(ctx context.Context, userID string, disabled bool) error {
+	if _, err := us.authClient.UpdateUser(ctx, userID, (&auth.UserToUpdate{}).Disabled(disabled)); err != nil {
+		log.Printf("user-service: error updating firebase user: %v", err)
+		return fmt.Errorf("error updating firebase user: %v", err)
+	}
+
+	if !disabled {
+		return nil
+	}
+
+	if err := us.authClient.RevokeRefreshTokens(ctx, userID); err != nil {
+		log.Printf("user-service: error revoking tokens: %v", err)
+		return fmt.Errorf("error revoking tokens: %v", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package stores
 
 import (
+	"app/internal/common"
+	"app/internal/models"
 	"context"
 	"database/sql"
 	"fmt"
@@ -15,20 +17,140 @@ func NewAdminStore(db *sql.DB) *AdminStore {
 	return &AdminStore{db: db}
 }
 
-func (s *AdminStore) IsAdmin(ctx context.Context, userID string) (bool, error) {
+const roleGrantColumns = `id, user_id, role, contest_id, granted_by, granted_at`
+
+func scanRoleGrant(row rowScanner, g *models.RoleGrant) error {
+	var contestID, grantedBy sql.NullString
+
+	if err := row.Scan(&g.ID, &g.UserID, &g.Role, &contestID, &grantedBy, &g.GrantedAt); err != nil {
+		return err
+	}
+
+	g.ContestID = contestID.String
+	g.GrantedBy = grantedBy.String
+	return nil
+}
+
+// ListGrants lists the role grants of every admin, or only of one user when userID is set
+func (s *AdminStore) ListGrants(ctx context.Context, userID string) (models.Grants, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("admin store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + roleGrantColumns + `
+		FROM admin_roles
+		WHERE $1 = '' OR user_id = $1
+		ORDER BY user_id, granted_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		log.Printf("admin-store: query failed: %v", err)
+		return nil, fmt.Errorf("query role grants: %w", err)
+	}
+	defer rows.Close()
+
+	grants := make(models.Grants, 0)
+	for rows.Next() {
+		var g models.RoleGrant
+		if err := scanRoleGrant(rows, &g); err != nil {
+			log.Printf("admin-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan role grant row: %w", err)
+		}
+		grants = append(grants, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("admin-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return grants, nil
+}
+
+func (s *AdminStore) GrantRole(ctx context.Context, g *models.RoleGrant) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("admin store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO admin_roles (` + roleGrantColumns + `)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)
+		ON CONFLICT DO NOTHING
+	`
+
+	res, err := s.db.ExecContext(ctx, q, g.ID, g.UserID, g.Role, g.ContestID, g.GrantedBy, g.GrantedAt)
+	if err != nil {
+		log.Printf("admin-store: insert failed: %v", err)
+		return fmt.Errorf("insert role grant: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("admin-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.RoleAlreadyGrantedError
+	}
+
+	return nil
+}
+
+// RevokeRole deletes a role grant. The last global super admin grant cannot be revoked,
+// so that someone can always manage roles.
+func (s *AdminStore) RevokeRole(ctx context.Context, grantID string) error {
 	if s == nil || s.db == nil {
-		return false, fmt.Errorf("admin store: db is not initialized")
+		return fmt.Errorf("admin store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("admin-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
 	}
+	defer tx.Rollback()
 
-	var exists bool
-	query := "SELECT EXISTS(SELECT 1 FROM admin WHERE user_id = $1)"
+	// Serialize revocations so that two super admins cannot revoke each other at once
+	if _, err := tx.ExecContext(ctx, `LOCK TABLE admin_roles IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		log.Printf("admin-store: lock failed: %v", err)
+		return fmt.Errorf("lock role grants: %w", err)
+	}
 
-	row := s.db.QueryRowContext(ctx, query, userID)
-	err := row.Scan(&exists)
+	res, err := tx.ExecContext(ctx, `DELETE FROM admin_roles WHERE id = $1`, grantID)
 	if err != nil {
+		log.Printf("admin-store: delete failed: %v", err)
+		return fmt.Errorf("delete role grant: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("admin-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.RoleGrantNotFoundError
+	}
+
+	const countQ = `SELECT COUNT(*) FROM admin_roles WHERE role = 'super_admin' AND contest_id IS NULL`
+
+	var superAdmins int
+	if err := tx.QueryRowContext(ctx, countQ).Scan(&superAdmins); err != nil {
 		log.Printf("admin-store: query failed: %v", err)
-		return false, fmt.Errorf("query admin status: %w", err)
+		return fmt.Errorf("count super admins: %w", err)
+	}
+
+	if superAdmins == 0 {
+		return common.LastSuperAdminError
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("admin-store: commit failed: %v", err)
+		return fmt.Errorf("commit revocation: %w", err)
 	}
 
-	return exists, nil
+	return nil
 }
@@ -0,0 +1,224 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+type AnnouncementStore struct {
+	db *sql.DB
+}
+
+func NewAnnouncementStore(db *sql.DB) *AnnouncementStore {
+	return &AnnouncementStore{
+		db: db,
+	}
+}
+
+const announcementColumns = `id, contest_id, problem_id, title, body, created_by, created_at, updated_at`
+
+func scanAnnouncement(row rowScanner, a *models.Announcement) error {
+	var problemID sql.NullString
+
+	if err := row.Scan(&a.ID, &a.ContestID, &problemID, &a.Title, &a.Body, &a.CreatedBy, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return err
+	}
+
+	a.ProblemID = problemID.String
+	return nil
+}
+
+// ListAnnouncements lists the announcements of a contest, newest first
+func (s *AnnouncementStore) ListAnnouncements(ctx context.Context, contestID string) ([]models.Announcement, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("announcement store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + announcementColumns + `
+		FROM announcements
+		WHERE contest_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID)
+	if err != nil {
+		log.Printf("announcement-store: query failed: %v", err)
+		return nil, fmt.Errorf("query announcements: %w", err)
+	}
+	defer rows.Close()
+
+	announcements := make([]models.Announcement, 0)
+	for rows.Next() {
+		var a models.Announcement
+		if err := scanAnnouncement(rows, &a); err != nil {
+			log.Printf("announcement-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan announcement row: %w", err)
+		}
+		announcements = append(announcements, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("announcement-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return announcements, nil
+}
+
+func (s *AnnouncementStore) GetAnnouncement(ctx context.Context, contestID string, announcementID string) (*models.Announcement, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("announcement store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + announcementColumns + `
+		FROM announcements
+		WHERE id = $1 AND contest_id = $2
+	`
+
+	var a models.Announcement
+	if err := scanAnnouncement(s.db.QueryRowContext(ctx, q, announcementID, contestID), &a); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.AnnouncementNotFoundError
+		}
+		log.Printf("announcement-store: query failed: %v", err)
+		return nil, fmt.Errorf("query announcement: %w", err)
+	}
+
+	return &a, nil
+}
+
+func (s *AnnouncementStore) CreateAnnouncement(ctx context.Context, a *models.Announcement) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("announcement store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO announcements (` + announcementColumns + `)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8)
+	`
+
+	_, err := s.db.ExecContext(ctx, q, a.ID, a.ContestID, a.ProblemID, a.Title, a.Body, a.CreatedBy, a.CreatedAt, a.UpdatedAt)
+	if err != nil {
+		log.Printf("announcement-store: insert failed: %v", err)
+		return fmt.Errorf("insert announcement: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AnnouncementStore) UpdateAnnouncement(ctx context.Context, a *models.Announcement) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("announcement store: db is not initialized")
+	}
+
+	const q = `
+		UPDATE announcements
+		SET problem_id = NULLIF($3, ''), title = $4, body = $5, updated_at = $6
+		WHERE id = $1 AND contest_id = $2
+	`
+
+	res, err := s.db.ExecContext(ctx, q, a.ID, a.ContestID, a.ProblemID, a.Title, a.Body, a.UpdatedAt)
+	if err != nil {
+		log.Printf("announcement-store: update failed: %v", err)
+		return fmt.Errorf("update announcement: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("announcement-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.AnnouncementNotFoundError
+	}
+
+	return nil
+}
+
+func (s *AnnouncementStore) DeleteAnnouncement(ctx context.Context, contestID string, announcementID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("announcement store: db is not initialized")
+	}
+
+	const q = `DELETE FROM announcements WHERE id = $1 AND contest_id = $2`
+
+	res, err := s.db.ExecContext(ctx, q, announcementID, contestID)
+	if err != nil {
+		log.Printf("announcement-store: delete failed: %v", err)
+		return fmt.Errorf("delete announcement: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("announcement-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.AnnouncementNotFoundError
+	}
+
+	return nil
+}
+
+// AnnouncementEventsChannel is the Postgres notification channel announcement events are published on
+const AnnouncementEventsChannel = "announcement_events"
+
+// announcementNotification is the payload of an announcement event notification. It carries
+// identifiers only since notification payloads are limited to 8000 bytes.
+type announcementNotification struct {
+	Type           models.AnnouncementEventType `json:"type"`
+	AnnouncementID string                       `json:"announcement_id"`
+	ContestID      string                       `json:"contest_id"`
+	ProblemID      string                       `json:"problem_id,omitempty"`
+}
+
+// PublishAnnouncementEvent notifies every app instance listening on AnnouncementEventsChannel
+func (s *AnnouncementStore) PublishAnnouncementEvent(ctx context.Context, event models.AnnouncementEvent) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("announcement store: db is not initialized")
+	}
+
+	payload, err := json.Marshal(announcementNotification{
+		Type:           event.Type,
+		AnnouncementID: event.Announcement.ID,
+		ContestID:      event.Announcement.ContestID,
+		ProblemID:      event.Announcement.ProblemID,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal announcement event: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, AnnouncementEventsChannel, string(payload)); err != nil {
+		log.Printf("announcement-store: notify failed: %v", err)
+		return fmt.Errorf("notify announcement event: %w", err)
+	}
+
+	return nil
+}
+
+// ParseAnnouncementEvent decodes a notification sent by PublishAnnouncementEvent. The announcement
+// only has its ID, contest and problem set, callers load the rest if they need it.
+func ParseAnnouncementEvent(payload string) (models.AnnouncementEvent, error) {
+	var n announcementNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return models.AnnouncementEvent{}, fmt.Errorf("unmarshal announcement event: %w", err)
+	}
+
+	return models.AnnouncementEvent{
+		Type: n.Type,
+		Announcement: models.Announcement{
+			ID:        n.AnnouncementID,
+			ContestID: n.ContestID,
+			ProblemID: n.ProblemID,
+		},
+	}, nil
+}
@@ -0,0 +1,156 @@
+package stores
+
+import (
+	"app/internal/models"
+	"app/internal/models/dto"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+type AuditStore struct {
+	db *sql.DB
+}
+
+func NewAuditStore(db *sql.DB) *AuditStore {
+	return &AuditStore{
+		db: db,
+	}
+}
+
+const auditColumns = `id, actor_id, action, target, contest_id, before, after, changes, request_id, ip, created_at`
+
+func scanAuditEntry(row rowScanner, e *models.AuditEntry) error {
+	var contestID sql.NullString
+	var target, before, after, changes []byte
+
+	if err := row.Scan(&e.ID, &e.ActorID, &e.Action, &target, &contestID, &before, &after, &changes, &e.RequestID, &e.IP, &e.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(target, &e.Target); err != nil {
+		return err
+	}
+
+	if changes != nil {
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return err
+		}
+	}
+
+	e.ContestID = contestID.String
+	e.Before = before
+	e.After = after
+	return nil
+}
+
+// nullJSON passes empty JSON to the database as NULL
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
+
+func (s *AuditStore) CreateEntry(ctx context.Context, e *models.AuditEntry) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("audit store: db is not initialized")
+	}
+
+	target, err := json.Marshal(e.Target)
+	if err != nil {
+		return fmt.Errorf("marshal target: %w", err)
+	}
+
+	var changes []byte
+	if len(e.Changes) > 0 {
+		if changes, err = json.Marshal(e.Changes); err != nil {
+			return fmt.Errorf("marshal changes: %w", err)
+		}
+	}
+
+	const q = `
+		INSERT INTO audit_log (actor_id, action, target, contest_id, before, after, changes, request_id, ip, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	err = s.db.QueryRowContext(ctx, q, e.ActorID, e.Action, string(target), e.ContestID,
+		nullJSON(e.Before), nullJSON(e.After), nullJSON(changes), e.RequestID, e.IP, e.CreatedAt).Scan(&e.ID)
+	if err != nil {
+		log.Printf("audit-store: insert failed: %v", err)
+		return fmt.Errorf("insert audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListEntries searches the audit log, newest first. Empty filters match everything.
+func (s *AuditStore) ListEntries(ctx context.Context, req *dto.ListAuditLogRequest) ([]models.AuditEntry, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("audit store: db is not initialized")
+	}
+
+	const pageSize = 20
+
+	offset := req.Page * pageSize
+
+	const q = `
+		SELECT ` + auditColumns + `
+		FROM audit_log
+		WHERE ($1 = '' OR actor_id = $1)
+			AND ($2 = '' OR action ILIKE '%' || $2 || '%')
+			AND ($3 = '' OR contest_id = $3)
+			AND ($4 = 0 OR created_at >= $4)
+			AND ($5 = 0 OR created_at <= $5)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $6 OFFSET $7
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, req.ActorID, req.Action, req.ContestID, req.From, req.To, pageSize, offset)
+	if err != nil {
+		log.Printf("audit-store: query failed: %v", err)
+		return nil, fmt.Errorf("query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.AuditEntry, 0)
+	for rows.Next() {
+		var e models.AuditEntry
+		if err := scanAuditEntry(rows, &e); err != nil {
+			log.Printf("audit-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan audit entry row: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("audit-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// PurgeEntries deletes the entries created before the cutoff and returns how many were deleted
+func (s *AuditStore) PurgeEntries(ctx context.Context, cutoff int64) (int64, error) {
+	if s == nil || s.db == nil {
+		return 0, fmt.Errorf("audit store: db is not initialized")
+	}
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM audit_log WHERE created_at < $1`, cutoff)
+	if err != nil {
+		log.Printf("audit-store: delete failed: %v", err)
+		return 0, fmt.Errorf("purge audit log: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("audit-store: rows error %v", err)
+		return 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,240 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+type ClarificationStore struct {
+	db *sql.DB
+}
+
+func NewClarificationStore(db *sql.DB) *ClarificationStore {
+	return &ClarificationStore{
+		db: db,
+	}
+}
+
+const clarificationColumns = `id, contest_id, problem_id, user_id, question, status, answer, public, answered_by, created_at, answered_at`
+
+func scanClarification(row rowScanner, c *models.Clarification) error {
+	var problemID, answer, answeredBy sql.NullString
+	var answeredAt sql.NullInt64
+
+	err := row.Scan(
+		&c.ID,
+		&c.ContestID,
+		&problemID,
+		&c.UserID,
+		&c.Question,
+		&c.Status,
+		&answer,
+		&c.Public,
+		&answeredBy,
+		&c.CreatedAt,
+		&answeredAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.ProblemID = problemID.String
+	c.Answer = answer.String
+	c.AnsweredBy = answeredBy.String
+	c.AnsweredAt = answeredAt.Int64
+	return nil
+}
+
+func (s *ClarificationStore) queryClarifications(ctx context.Context, q string, args ...any) ([]models.Clarification, error) {
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		log.Printf("clarification-store: query failed: %v", err)
+		return nil, fmt.Errorf("query clarifications: %w", err)
+	}
+	defer rows.Close()
+
+	clarifications := make([]models.Clarification, 0)
+	for rows.Next() {
+		var c models.Clarification
+		if err := scanClarification(rows, &c); err != nil {
+			log.Printf("clarification-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan clarification row: %w", err)
+		}
+		clarifications = append(clarifications, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("clarification-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return clarifications, nil
+}
+
+// ListClarifications is the admin queue of a contest, oldest first so pending questions are answered in order
+func (s *ClarificationStore) ListClarifications(ctx context.Context, contestID string, status models.ClarificationStatus, page int) ([]models.Clarification, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("clarification store: db is not initialized")
+	}
+
+	const pageSize = 20
+	page = max(0, page)
+	offset := page * pageSize
+
+	const q = `
+		SELECT ` + clarificationColumns + `
+		FROM clarifications
+		WHERE contest_id = $1 AND ($2 = '' OR status::TEXT = $2)
+		ORDER BY created_at ASC
+		LIMIT $3 OFFSET $4
+	`
+
+	return s.queryClarifications(ctx, q, contestID, string(status), pageSize, offset)
+}
+
+// ListUserClarifications lists the clarifications a user asked in a contest together with the
+// ones broadcast to everyone, newest first. Who asked and answered is only kept on the user's own.
+func (s *ClarificationStore) ListUserClarifications(ctx context.Context, contestID string, userID string) ([]models.Clarification, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("clarification store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + clarificationColumns + `
+		FROM clarifications
+		WHERE contest_id = $1 AND (user_id = $2 OR public)
+		ORDER BY created_at DESC
+	`
+
+	clarifications, err := s.queryClarifications(ctx, q, contestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range clarifications {
+		if clarifications[i].UserID != userID {
+			clarifications[i].UserID = ""
+			clarifications[i].AnsweredBy = ""
+		}
+	}
+
+	return clarifications, nil
+}
+
+func (s *ClarificationStore) GetClarification(ctx context.Context, contestID string, clarificationID string) (*models.Clarification, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("clarification store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + clarificationColumns + `
+		FROM clarifications
+		WHERE id = $1 AND contest_id = $2
+	`
+
+	var c models.Clarification
+	if err := scanClarification(s.db.QueryRowContext(ctx, q, clarificationID, contestID), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ClarificationNotFoundError
+		}
+		log.Printf("clarification-store: query failed: %v", err)
+		return nil, fmt.Errorf("query clarification: %w", err)
+	}
+
+	return &c, nil
+}
+
+// CreateClarification records a question, unless the user already asked limit questions in the
+// contest since a Unix timestamp. The user's registration is locked so that concurrent questions
+// are counted one after another.
+func (s *ClarificationStore) CreateClarification(ctx context.Context, c *models.Clarification, since int64, limit int) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("clarification store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("clarification-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const lockQ = `
+		SELECT 1
+		FROM contest_registrations
+		WHERE contest_id = $1 AND user_id = $2
+		FOR UPDATE
+	`
+
+	var registered int
+	if err := tx.QueryRowContext(ctx, lockQ, c.ContestID, c.UserID).Scan(&registered); err != nil {
+		if err == sql.ErrNoRows {
+			return common.UserNotRegisteredError
+		}
+		log.Printf("clarification-store: lock failed: %v", err)
+		return fmt.Errorf("lock registration: %w", err)
+	}
+
+	const countQ = `
+		SELECT COUNT(*)
+		FROM clarifications
+		WHERE contest_id = $1 AND user_id = $2 AND created_at >= $3
+	`
+
+	var count int
+	if err := tx.QueryRowContext(ctx, countQ, c.ContestID, c.UserID, since).Scan(&count); err != nil {
+		log.Printf("clarification-store: count failed: %v", err)
+		return fmt.Errorf("count clarifications: %w", err)
+	}
+
+	if count >= limit {
+		return common.ClarificationRateLimitedError
+	}
+
+	const q = `
+		INSERT INTO clarifications (id, contest_id, problem_id, user_id, question, status, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)
+	`
+
+	_, err = tx.ExecContext(ctx, q, c.ID, c.ContestID, c.ProblemID, c.UserID, c.Question, c.Status, c.CreatedAt)
+	if err != nil {
+		log.Printf("clarification-store: insert failed: %v", err)
+		return fmt.Errorf("insert clarification: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("clarification-store: commit failed: %v", err)
+		return fmt.Errorf("commit clarification: %w", err)
+	}
+
+	return nil
+}
+
+// AnswerClarification records the answer to a clarification. A broadcast answer stays public.
+func (s *ClarificationStore) AnswerClarification(ctx context.Context, c *models.Clarification) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("clarification store: db is not initialized")
+	}
+
+	const q = `
+		UPDATE clarifications
+		SET status = $3, answer = $4, public = public OR $5, answered_by = $6, answered_at = $7
+		WHERE id = $1 AND contest_id = $2
+		RETURNING public
+	`
+
+	err := s.db.QueryRowContext(ctx, q, c.ID, c.ContestID, c.Status, c.Answer, c.Public, c.AnsweredBy, c.AnsweredAt).Scan(&c.Public)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return common.ClarificationNotFoundError
+		}
+		log.Printf("clarification-store: update failed: %v", err)
+		return fmt.Errorf("answer clarification: %w", err)
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/labstack/gommon/log"
+	"github.com/lib/pq"
 )
 
 type ContestStore struct {
@@ -24,7 +25,8 @@ func NewContestStore(db *sql.DB) *ContestStore {
 	}
 }
 
-func (s *ContestStore) ListContests(ctx context.Context, page int) ([]models.Contest, error) {
+// ListContests returns a page of contests in any of the given statuses, or all contests if none are given
+func (s *ContestStore) ListContests(ctx context.Context, page int, statuses []models.ContestStatus, includePrivate bool) ([]models.Contest, error) {
 	if s == nil || s.db == nil {
 		return nil, fmt.Errorf("contest store: db is not initialized")
 	}
@@ -34,13 +36,19 @@ func (s *ContestStore) ListContests(ctx context.Context, page int) ([]models.Con
 	offset := page * pageSize
 
 	const q = `
-		SELECT id, name, registration_start_time, registration_end_time, start_time, end_time, eligible_to, description
+		SELECT ` + contestColumns + `
 		FROM contests
+		WHERE NOT is_template AND (cardinality($3::TEXT[]) = 0 OR status::TEXT = ANY($3)) AND ($4 OR NOT private)
 		ORDER BY start_time DESC
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := s.db.QueryContext(ctx, q, pageSize, offset)
+	statusFilter := make([]string, len(statuses))
+	for i, status := range statuses {
+		statusFilter[i] = string(status)
+	}
+
+	rows, err := s.db.QueryContext(ctx, q, pageSize, offset, pq.Array(statusFilter), includePrivate)
 	if err != nil {
 		log.Printf("contest-store: query failed: %v", err)
 		return nil, fmt.Errorf("query contests: %w", err)
@@ -50,24 +58,10 @@ func (s *ContestStore) ListContests(ctx context.Context, page int) ([]models.Con
 	contests := make([]models.Contest, 0)
 	for rows.Next() {
 		var c models.Contest
-		var eligibility sql.NullString
-
-		if err := rows.Scan(&c.ID, &c.Name, &c.RegistrationStartTime, &c.RegistrationEndTime, &c.StartTime, &c.EndTime, &eligibility, &c.Description); err != nil {
+		if err := scanContest(rows, &c); err != nil {
 			log.Printf("contest-store: row scan failed: %v", err)
 			return nil, fmt.Errorf("scan contest row: %w", err)
 		}
-
-		if eligibility.Valid {
-			eligibilityYears := strings.Split(eligibility.String, ",")
-			for _, yearStr := range eligibilityYears {
-				year, err := strconv.Atoi(yearStr)
-				if err != nil {
-					log.Printf("contest-store: invalid eligibility year: %v", err)
-					continue
-				}
-				c.EligibleTo = append(c.EligibleTo, year)
-			}
-		}
 		contests = append(contests, c)
 	}
 
@@ -103,13 +97,168 @@ func (s *ContestStore) CreateContest(ctx context.Context, c *models.Contest) err
 		return fmt.Errorf("contest store: db is not initialized")
 	}
 
+	if err := insertContest(ctx, s.db, c); err != nil {
+		log.Printf("contest-store: insert failed: %v", err)
+		return fmt.Errorf("insert contest: %w", err)
+	}
+
+	return nil
+}
+
+// CreateContestWithProblems creates a contest together with its problems, test cases, pools
+// and sections in a single transaction. Used when importing and cloning contests.
+func (s *ContestStore) CreateContestWithProblems(ctx context.Context, c *models.Contest, problems []models.Problem, testCases []models.TestCase, pools []models.ContestPool, sections []models.ContestSection) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("contest store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("contest-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertContest(ctx, tx, c); err != nil {
+		log.Printf("contest-store: insert failed: %v", err)
+		return fmt.Errorf("insert contest: %w", err)
+	}
+
+	for _, sec := range sections {
+		sec.ContestID = c.ID
+		if err := insertSection(ctx, tx, &sec); err != nil {
+			log.Printf("contest-store: insert section failed: %v", err)
+			return fmt.Errorf("insert section: %w", err)
+		}
+	}
+
+	for _, p := range problems {
+		p.ContestID = c.ID
+		if err := insertProblem(ctx, tx, &p); err != nil {
+			log.Printf("contest-store: insert problem failed: %v", err)
+			return fmt.Errorf("insert problem: %w", err)
+		}
+	}
+
+	const testCaseQ = `
+        INSERT INTO test_cases (id, problem_id, is_sample, created_at)
+        VALUES ($1, $2, $3, $4)
+    `
+
+	for _, tc := range testCases {
+		_, err := tx.ExecContext(ctx, testCaseQ, tc.ID, tc.ProblemID, tc.IsSample, tc.CreatedAt)
+		if err != nil {
+			log.Printf("contest-store: insert test case failed: %v", err)
+			return fmt.Errorf("insert test case: %w", err)
+		}
+	}
+
+	for _, pool := range pools {
+		pool.ContestID = c.ID
+		if err := upsertPool(ctx, tx, &pool); err != nil {
+			log.Printf("contest-store: insert pool failed: %v", err)
+			return fmt.Errorf("insert pool: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("contest-store: commit failed: %v", err)
+		return fmt.Errorf("commit contest: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ContestStore) ListTemplates(ctx context.Context, page int) ([]models.Contest, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const pageSize = 20
+	page = max(0, page)
+	offset := page * pageSize
+
+	const q = `
+		SELECT ` + contestColumns + `
+		FROM contests
+		WHERE is_template
+		ORDER BY name ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, pageSize, offset)
+	if err != nil {
+		log.Printf("contest-store: query failed: %v", err)
+		return nil, fmt.Errorf("query templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]models.Contest, 0)
+	for rows.Next() {
+		var c models.Contest
+		if err := scanContest(rows, &c); err != nil {
+			log.Printf("contest-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan template row: %w", err)
+		}
+		templates = append(templates, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("contest-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return templates, nil
+}
+
+// contestColumns lists the columns read by scanContest, in order
+const contestColumns = `id, name, registration_start_time, registration_end_time, start_time, end_time, eligible_to, description, status, is_template, duration, clamp_score, shuffle_problems, shuffle_options, practice_mode, max_participants, departments, usn_pattern, invite_only, private, team_mode, min_team_size, max_team_size`
+
+func scanContest(row rowScanner, c *models.Contest) error {
+	var eligibility, description sql.NullString
+
+	err := row.Scan(
+		&c.ID,
+		&c.Name,
+		&c.RegistrationStartTime,
+		&c.RegistrationEndTime,
+		&c.StartTime,
+		&c.EndTime,
+		&eligibility,
+		&description,
+		&c.Status,
+		&c.IsTemplate,
+		&c.Duration,
+		&c.ClampScore,
+		&c.ShuffleProblems,
+		&c.ShuffleOptions,
+		&c.PracticeMode,
+		&c.MaxParticipants,
+		pq.Array(&c.Departments),
+		&c.USNPattern,
+		&c.InviteOnly,
+		&c.Private,
+		&c.TeamMode,
+		&c.MinTeamSize,
+		&c.MaxTeamSize,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.Description = description.String
+	c.EligibleTo = parseEligibility(eligibility)
+	return nil
+}
+
+func insertContest(ctx context.Context, db execer, c *models.Contest) error {
 	const q = `
-        INSERT INTO contests (id, name, registration_start_time, registration_end_time, start_time, end_time, eligible_to, description)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        INSERT INTO contests (` + contestColumns + `)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, COALESCE($17::TEXT[], '{}'), $18, $19, $20, $21, $22, $23)
     `
 
 	eligibilityStr := strings.Join(intSliceToStringSlice(c.EligibleTo), ",")
-	_, err := s.db.ExecContext(ctx, q,
+	_, err := db.ExecContext(ctx, q,
 		c.ID,
 		c.Name,
 		c.RegistrationStartTime,
@@ -118,14 +267,40 @@ func (s *ContestStore) CreateContest(ctx context.Context, c *models.Contest) err
 		c.EndTime,
 		eligibilityStr,
 		c.Description,
+		c.Status,
+		c.IsTemplate,
+		c.Duration,
+		c.ClampScore,
+		c.ShuffleProblems,
+		c.ShuffleOptions,
+		c.PracticeMode,
+		c.MaxParticipants,
+		pq.Array(c.Departments),
+		c.USNPattern,
+		c.InviteOnly,
+		c.Private,
+		c.TeamMode,
+		c.MinTeamSize,
+		c.MaxTeamSize,
 	)
+	return err
+}
 
-	if err != nil {
-		log.Printf("contest-store: insert failed: %v", err)
-		return fmt.Errorf("insert contest: %w", err)
+func parseEligibility(eligibility sql.NullString) []int {
+	var years []int
+	if !eligibility.Valid {
+		return years
 	}
 
-	return nil
+	for _, yearStr := range strings.Split(eligibility.String, ",") {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			log.Printf("contest-store: invalid eligibility year: %v", err)
+			continue
+		}
+		years = append(years, year)
+	}
+	return years
 }
 
 func intSliceToStringSlice(i []int) []string {
@@ -149,11 +324,35 @@ func (s *ContestStore) UpdateContest(ctx context.Context, c *models.Contest) err
             start_time = $5,
             end_time = $6,
 			eligible_to = $7,
-			description = $8
+			description = $8,
+			duration = $9,
+			clamp_score = $10,
+			shuffle_problems = $11,
+			shuffle_options = $12,
+			practice_mode = $13,
+			max_participants = $14,
+			departments = COALESCE($15::TEXT[], '{}'),
+			usn_pattern = $16,
+			invite_only = $17,
+			private = $18,
+			team_mode = $19,
+			min_team_size = $20,
+			max_team_size = $21
         WHERE id = $1
     `
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("contest-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockContestSeats(ctx, tx, c.ID); err != nil {
+		return err
+	}
+
 	eligibilityStr := strings.Join(intSliceToStringSlice(c.EligibleTo), ",")
-	_, err := s.db.ExecContext(ctx, q,
+	_, err = tx.ExecContext(ctx, q,
 		c.ID,
 		c.Name,
 		c.RegistrationStartTime,
@@ -162,12 +361,63 @@ func (s *ContestStore) UpdateContest(ctx context.Context, c *models.Contest) err
 		c.EndTime,
 		eligibilityStr,
 		c.Description,
+		c.Duration,
+		c.ClampScore,
+		c.ShuffleProblems,
+		c.ShuffleOptions,
+		c.PracticeMode,
+		c.MaxParticipants,
+		pq.Array(c.Departments),
+		c.USNPattern,
+		c.InviteOnly,
+		c.Private,
+		c.TeamMode,
+		c.MinTeamSize,
+		c.MaxTeamSize,
 	)
 
 	if err != nil {
 		log.Printf("contest-store: update failed: %v", err)
 		return fmt.Errorf("update contest: %w", err)
 	}
+
+	// Added seats go to the waitlist
+	if err := promoteWaitlist(ctx, tx, c.ID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("contest-store: commit failed: %v", err)
+		return fmt.Errorf("commit contest: %w", err)
+	}
+	return nil
+}
+
+// UpdateContestStatus moves a contest from one status to another. A contest whose status
+// is no longer from is left as it is.
+func (s *ContestStore) UpdateContestStatus(ctx context.Context, contestID string, from models.ContestStatus, to models.ContestStatus) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `UPDATE contests SET status = $3 WHERE id = $1 AND status = $2`
+
+	res, err := s.db.ExecContext(ctx, q, contestID, from, to)
+	if err != nil {
+		log.Printf("contest-store: update status failed: %v", err)
+		return fmt.Errorf("update contest status: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("contest-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.InvalidStatusTransitionError
+	}
+
 	return nil
 }
 
@@ -188,17 +438,13 @@ func (s *ContestStore) DeleteContest(ctx context.Context, contestID string) erro
 
 func (s *ContestStore) GetContest(ctx context.Context, contestID string) (*dto.GetContestResponse, error) {
 	const q = `
-		SELECT id, name, registration_start_time, registration_end_time, start_time, end_time, eligible_to, description
+		SELECT ` + contestColumns + `
 		FROM contests
 		WHERE id = $1
 	`
 
 	var c dto.GetContestResponse
-	var eligibility sql.NullString
-	err := s.db.QueryRowContext(ctx, q, contestID).Scan(
-		&c.ID, &c.Name, &c.RegistrationStartTime, &c.RegistrationEndTime, &c.StartTime, &c.EndTime, &eligibility, &c.Description,
-	)
-	if err != nil {
+	if err := scanContest(s.db.QueryRowContext(ctx, q, contestID), &c.Contest); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.ContestNotFoundError
 		}
@@ -206,71 +452,451 @@ func (s *ContestStore) GetContest(ctx context.Context, contestID string) (*dto.G
 		return nil, fmt.Errorf("query contest: %w", err)
 	}
 
-	if eligibility.Valid {
-		eligibilityYears := strings.Split(eligibility.String, ",")
-		for _, yearStr := range eligibilityYears {
-			year, err := strconv.Atoi(yearStr)
-			if err != nil {
-				log.Printf("contest-store: invalid eligibility year: %v", err)
-				continue
-			}
-			c.EligibleTo = append(c.EligibleTo, year)
+	return &c, nil
+}
+
+// RegisterUser registers a user, or puts them on the waitlist if the contest is full.
+// An invite code, when given, is redeemed along with the registration. It reports whether
+// the user was waitlisted.
+func (s *ContestStore) RegisterUser(ctx context.Context, contestID string, userID string, inviteCode string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Errorf("contest-store: begin tx failed: %v", err)
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	maxParticipants, err := lockContestSeats(ctx, tx, contestID)
+	if err != nil {
+		return false, err
+	}
+
+	const waitingQ = `SELECT EXISTS (SELECT 1 FROM contest_waitlist WHERE contest_id = $1 AND user_id = $2)`
+
+	var waiting bool
+	if err := tx.QueryRowContext(ctx, waitingQ, contestID, userID).Scan(&waiting); err != nil {
+		log.Errorf("contest-store: query failed: %v", err)
+		return false, fmt.Errorf("query waitlist: %w", err)
+	}
+
+	if waiting {
+		log.Errorf("contest-store: user %s already waitlisted", userID)
+		return false, common.UserAlreadyExistsError
+	}
+
+	if inviteCode != "" {
+		if err := redeemInviteCode(ctx, tx, contestID, inviteCode, userID, time.Now().UnixMilli()); err != nil {
+			return false, err
 		}
 	}
 
-	return &c, nil
-}
+	const countQ = `SELECT COUNT(*) FROM contest_registrations WHERE contest_id = $1`
 
-func (s *ContestStore) RegisterUser(ctx context.Context, contestID string, userID string) error {
-	const q = `
+	var registered int
+	if err := tx.QueryRowContext(ctx, countQ, contestID).Scan(&registered); err != nil {
+		log.Errorf("contest-store: query failed: %v", err)
+		return false, fmt.Errorf("count registrations: %w", err)
+	}
+
+	full := maxParticipants > 0 && registered >= maxParticipants
+
+	q := `
 		INSERT INTO contest_registrations (contest_id, user_id, registered_at)
 		VALUES ($1, $2, $3)
 		ON CONFLICT (contest_id, user_id) DO NOTHING
 		`
+	if full {
+		// Registered users keep their seat, only new ones wait
+		q = `
+		INSERT INTO contest_waitlist (contest_id, user_id, joined_at)
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (SELECT 1 FROM contest_registrations WHERE contest_id = $1 AND user_id = $2)
+		`
+	}
 
-	res, err := s.db.ExecContext(ctx, q, contestID, userID, time.Now().Unix())
+	res, err := tx.ExecContext(ctx, q, contestID, userID, time.Now().Unix())
 	if err != nil {
 		log.Errorf("contest-store: query failed: %v", err)
-		return fmt.Errorf("query contest registration: %w", err)
+		return false, fmt.Errorf("query contest registration: %w", err)
 	}
 
 	// If rows affected is 0, then the user already registered
 	affected, err := res.RowsAffected()
 	if err != nil {
 		log.Errorf("user-store: rows error %v", err)
-		return fmt.Errorf("rows error: %w", err)
+		return false, fmt.Errorf("rows error: %w", err)
 	}
 
 	if affected == 0 {
 		log.Errorf("user-store: user %s already registered", userID)
-		return common.UserAlreadyExistsError
+		return false, common.UserAlreadyExistsError
 	}
 
-	return nil
+	if err := tx.Commit(); err != nil {
+		log.Errorf("contest-store: commit failed: %v", err)
+		return false, fmt.Errorf("commit registration: %w", err)
+	}
+
+	return full, nil
 }
 
+// UnregisterUser removes a user's registration or their place on the waitlist.
+// A freed seat goes to the first user on the waitlist.
 func (s *ContestStore) UnregisterUser(ctx context.Context, contestID string, userID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Errorf("contest-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockContestSeats(ctx, tx, contestID); err != nil {
+		return err
+	}
+
 	const q = `
-		DELETE FROM contest_registrations
+		WITH registration AS (
+			DELETE FROM contest_registrations
+			WHERE contest_id = $1 AND user_id = $2
+			RETURNING user_id
+		), waiting AS (
+			DELETE FROM contest_waitlist
+			WHERE contest_id = $1 AND user_id = $2
+			RETURNING user_id
+		)
+		SELECT (SELECT COUNT(*) FROM registration), (SELECT COUNT(*) FROM waiting)
+		`
+
+	var unregistered, unwaited int
+	if err := tx.QueryRowContext(ctx, q, contestID, userID).Scan(&unregistered, &unwaited); err != nil {
+		log.Errorf("contest-store: query failed: %v", err)
+		return fmt.Errorf("query contest registration: %w", err)
+	}
+
+	// If nothing was removed, then the user never registered
+	if unregistered == 0 && unwaited == 0 {
+		log.Errorf("user-store: user %s not registered", userID)
+		return common.UserNotFoundError
+	}
+
+	if unregistered > 0 {
+		if err := promoteWaitlist(ctx, tx, contestID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Errorf("contest-store: commit failed: %v", err)
+		return fmt.Errorf("commit registration: %w", err)
+	}
+
+	return nil
+}
+
+// lockContestSeats locks the contest row so seats are counted and filled one registration at a time
+func lockContestSeats(ctx context.Context, tx *sql.Tx, contestID string) (int, error) {
+	const q = `SELECT max_participants FROM contests WHERE id = $1 FOR UPDATE`
+
+	var maxParticipants int
+	if err := tx.QueryRowContext(ctx, q, contestID).Scan(&maxParticipants); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, common.ContestNotFoundError
+		}
+		log.Errorf("contest-store: query failed: %v", err)
+		return 0, fmt.Errorf("lock contest: %w", err)
+	}
+
+	return maxParticipants, nil
+}
+
+// promoteWaitlist registers waitlisted users in order until the contest is full.
+// The contest must be locked with lockContestSeats.
+func promoteWaitlist(ctx context.Context, tx *sql.Tx, contestID string) error {
+	const q = `
+		WITH seats AS (
+			SELECT CASE WHEN c.max_participants = 0 THEN NULL
+				ELSE GREATEST(c.max_participants - (SELECT COUNT(*) FROM contest_registrations r WHERE r.contest_id = c.id), 0) END AS open
+			FROM contests c
+			WHERE c.id = $1
+		), promoted AS (
+			DELETE FROM contest_waitlist
+			WHERE contest_id = $1 AND user_id IN (
+				SELECT user_id
+				FROM contest_waitlist
+				WHERE contest_id = $1
+				ORDER BY seq
+				LIMIT (SELECT open FROM seats)
+			)
+			RETURNING contest_id, user_id
+		)
+		INSERT INTO contest_registrations (contest_id, user_id, registered_at)
+		SELECT contest_id, user_id, $2
+		FROM promoted
+		ON CONFLICT (contest_id, user_id) DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, q, contestID, time.Now().Unix()); err != nil {
+		log.Errorf("contest-store: promote waitlist failed: %v", err)
+		return fmt.Errorf("promote waitlist: %w", err)
+	}
+
+	return nil
+}
+
+// GetWaitlistPosition returns the user's 1-based position on the waitlist, or 0 if they are not waiting
+func (s *ContestStore) GetWaitlistPosition(ctx context.Context, contestID string, userID string) (int, error) {
+	if s == nil || s.db == nil {
+		return 0, fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `
+		SELECT COUNT(*)
+		FROM contest_waitlist w
+		JOIN contest_waitlist me ON me.contest_id = w.contest_id AND me.user_id = $2
+		WHERE w.contest_id = $1 AND w.seq <= me.seq
+	`
+
+	var position int
+	if err := s.db.QueryRowContext(ctx, q, contestID, userID).Scan(&position); err != nil {
+		log.Errorf("contest-store: query failed: %v", err)
+		return 0, fmt.Errorf("query waitlist position: %w", err)
+	}
+
+	return position, nil
+}
+
+func (s *ContestStore) ListWaitlist(ctx context.Context, contestID string) ([]models.WaitlistEntry, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `
+		SELECT user_id, ROW_NUMBER() OVER (ORDER BY seq), joined_at
+		FROM contest_waitlist
+		WHERE contest_id = $1
+		ORDER BY seq
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID)
+	if err != nil {
+		log.Errorf("contest-store: query failed: %v", err)
+		return nil, fmt.Errorf("query waitlist: %w", err)
+	}
+	defer rows.Close()
+
+	waitlist := make([]models.WaitlistEntry, 0)
+	for rows.Next() {
+		var w models.WaitlistEntry
+		if err := rows.Scan(&w.UserID, &w.Position, &w.JoinedAt); err != nil {
+			log.Errorf("contest-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan waitlist row: %w", err)
+		}
+		waitlist = append(waitlist, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Errorf("contest-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return waitlist, nil
+}
+
+// StartAttempt records when the user started their timed attempt. Starting again keeps the first start.
+func (s *ContestStore) StartAttempt(ctx context.Context, contestID string, userID string, startedAt int64) error {
+	const q = `
+		UPDATE contest_registrations
+		SET started_at = COALESCE(started_at, $3)
 		WHERE contest_id = $1 AND user_id = $2
 		`
 
-	res, err := s.db.ExecContext(ctx, q, contestID, userID)
+	res, err := s.db.ExecContext(ctx, q, contestID, userID, startedAt)
 	if err != nil {
 		log.Errorf("contest-store: query failed: %v", err)
-		return fmt.Errorf("query contest registration: %w", err)
+		return fmt.Errorf("start attempt: %w", err)
 	}
 
-	// If rows affected is 0, then the user never registered
+	// If rows affected is 0, then the user is not registered
 	affected, err := res.RowsAffected()
 	if err != nil {
-		log.Errorf("user-store: rows error %v", err)
+		log.Errorf("contest-store: rows error %v", err)
 		return fmt.Errorf("rows error: %w", err)
 	}
 
 	if affected == 0 {
-		log.Errorf("user-store: user %s not registered", userID)
-		return common.UserNotFoundError
+		return common.UserNotRegisteredError
+	}
+
+	return nil
+}
+
+// GetAttemptStart returns when the user started their timed attempt, or 0 if they have not started
+func (s *ContestStore) GetAttemptStart(ctx context.Context, contestID string, userID string) (int64, error) {
+	const q = `
+		SELECT started_at
+		FROM contest_registrations
+		WHERE contest_id = $1 AND user_id = $2
+		`
+
+	var startedAt sql.NullInt64
+	err := s.db.QueryRowContext(ctx, q, contestID, userID).Scan(&startedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, common.UserNotRegisteredError
+		}
+		log.Errorf("contest-store: query failed: %v", err)
+		return 0, fmt.Errorf("query attempt: %w", err)
+	}
+
+	return startedAt.Int64, nil
+}
+
+// StartVirtual records when the user started a virtual participation. Starting again keeps the first start.
+func (s *ContestStore) StartVirtual(ctx context.Context, contestID string, userID string, startedAt int64) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO virtual_participations (contest_id, user_id, started_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (contest_id, user_id) DO NOTHING
+		`
+
+	if _, err := s.db.ExecContext(ctx, q, contestID, userID, startedAt); err != nil {
+		log.Errorf("contest-store: insert failed: %v", err)
+		return fmt.Errorf("start virtual participation: %w", err)
+	}
+
+	return nil
+}
+
+// GetVirtualStart returns when the user started a virtual participation, or 0 if they have not
+func (s *ContestStore) GetVirtualStart(ctx context.Context, contestID string, userID string) (int64, error) {
+	if s == nil || s.db == nil {
+		return 0, fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `
+		SELECT started_at
+		FROM virtual_participations
+		WHERE contest_id = $1 AND user_id = $2
+		`
+
+	var startedAt int64
+	if err := s.db.QueryRowContext(ctx, q, contestID, userID).Scan(&startedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		log.Errorf("contest-store: query failed: %v", err)
+		return 0, fmt.Errorf("query virtual participation: %w", err)
+	}
+
+	return startedAt, nil
+}
+
+// GetAccess returns whether the user is on the contest's allow or deny list, empty if neither
+func (s *ContestStore) GetAccess(ctx context.Context, contestID string, userID string) (models.ContestAccess, error) {
+	if s == nil || s.db == nil {
+		return "", fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `SELECT access FROM contest_access_list WHERE contest_id = $1 AND user_id = $2`
+
+	var access models.ContestAccess
+	if err := s.db.QueryRowContext(ctx, q, contestID, userID).Scan(&access); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		log.Errorf("contest-store: query failed: %v", err)
+		return "", fmt.Errorf("query access: %w", err)
+	}
+
+	return access, nil
+}
+
+func (s *ContestStore) ListAccess(ctx context.Context, contestID string) ([]models.AccessListEntry, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `
+		SELECT contest_id, user_id, access, added_by, added_at
+		FROM contest_access_list
+		WHERE contest_id = $1
+		ORDER BY added_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID)
+	if err != nil {
+		log.Errorf("contest-store: query failed: %v", err)
+		return nil, fmt.Errorf("query access list: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.AccessListEntry, 0)
+	for rows.Next() {
+		var e models.AccessListEntry
+		var addedBy sql.NullString
+		if err := rows.Scan(&e.ContestID, &e.UserID, &e.Access, &addedBy, &e.AddedAt); err != nil {
+			log.Errorf("contest-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan access row: %w", err)
+		}
+		e.AddedBy = addedBy.String
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Errorf("contest-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// SetAccess puts a user on the contest's allow or deny list, replacing any previous entry
+func (s *ContestStore) SetAccess(ctx context.Context, e *models.AccessListEntry) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO contest_access_list (contest_id, user_id, access, added_by, added_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		ON CONFLICT (contest_id, user_id) DO UPDATE
+		SET access = EXCLUDED.access, added_by = EXCLUDED.added_by, added_at = EXCLUDED.added_at
+	`
+
+	_, err := s.db.ExecContext(ctx, q, e.ContestID, e.UserID, e.Access, e.AddedBy, e.AddedAt)
+	if err != nil {
+		log.Errorf("contest-store: set access failed: %v", err)
+		return fmt.Errorf("set access: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ContestStore) DeleteAccess(ctx context.Context, contestID string, userID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("contest store: db is not initialized")
+	}
+
+	const q = `DELETE FROM contest_access_list WHERE contest_id = $1 AND user_id = $2`
+
+	res, err := s.db.ExecContext(ctx, q, contestID, userID)
+	if err != nil {
+		log.Errorf("contest-store: delete failed: %v", err)
+		return fmt.Errorf("delete access: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Errorf("contest-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.AccessEntryNotFoundError
 	}
 
 	return nil
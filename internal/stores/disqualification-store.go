@@ -0,0 +1,436 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+type DisqualificationStore struct {
+	db *sql.DB
+}
+
+func NewDisqualificationStore(db *sql.DB) *DisqualificationStore {
+	return &DisqualificationStore{
+		db: db,
+	}
+}
+
+// recordDisqualificationTx appends an entry to the disqualification history and applies it to
+// the user's ranking, if they have one, and to their team's. A user with a pending appeal cannot be disqualified again,
+// reinstating them accepts the appeal.
+func recordDisqualificationTx(ctx context.Context, tx *sql.Tx, d *models.Disqualification) error {
+	if d.Evidence == nil {
+		d.Evidence = []string{}
+	}
+
+	if len(d.Evidence) > 0 {
+		const evidenceQ = `
+			SELECT COUNT(*)
+			FROM submissions
+			WHERE id = ANY($1) AND contest_id = $2 AND user_id = $3
+		`
+
+		var count int
+		if err := tx.QueryRowContext(ctx, evidenceQ, pq.Array(d.Evidence), d.ContestID, d.UserID).Scan(&count); err != nil {
+			log.Printf("disqualification-store: evidence query failed: %v", err)
+			return fmt.Errorf("query evidence: %w", err)
+		}
+
+		if count != len(d.Evidence) {
+			return common.InvalidEvidenceError
+		}
+	}
+
+	if err := lockAppeals(ctx, tx, d.ContestID, d.UserID); err != nil {
+		return err
+	}
+
+	const latestQ = `
+		SELECT action
+		FROM disqualifications
+		WHERE contest_id = $1 AND user_id = $2
+		ORDER BY seq DESC
+		LIMIT 1
+	`
+
+	var latest models.DisqualificationAction
+	if err := tx.QueryRowContext(ctx, latestQ, d.ContestID, d.UserID).Scan(&latest); err != nil && err != sql.ErrNoRows {
+		log.Printf("disqualification-store: latest query failed: %v", err)
+		return fmt.Errorf("query latest disqualification: %w", err)
+	}
+
+	disqualified := latest == models.DisqualifyAction
+	if d.Action == models.DisqualifyAction && disqualified {
+		return common.UserAlreadyDisqualifiedError
+	}
+	if d.Action == models.ReinstateAction && !disqualified {
+		return common.UserNotDisqualifiedError
+	}
+
+	const pendingQ = `
+		SELECT id
+		FROM disqualification_appeals
+		WHERE contest_id = $1 AND user_id = $2 AND status = 'pending'
+	`
+
+	var appealID string
+	err := tx.QueryRowContext(ctx, pendingQ, d.ContestID, d.UserID).Scan(&appealID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("disqualification-store: appeal query failed: %v", err)
+		return fmt.Errorf("query pending appeal: %w", err)
+	}
+
+	if err == nil {
+		if d.Action == models.DisqualifyAction {
+			return common.AppealAlreadyPendingError
+		}
+
+		const acceptQ = `
+			UPDATE disqualification_appeals
+			SET status = 'accepted', response = $2, resolved_by = NULLIF($3, ''), resolved_at = $4
+			WHERE id = $1
+		`
+
+		if _, err := tx.ExecContext(ctx, acceptQ, appealID, d.Reason, d.AdminID, d.CreatedAt); err != nil {
+			log.Printf("disqualification-store: update appeal failed: %v", err)
+			return fmt.Errorf("update appeal: %w", err)
+		}
+	}
+
+	const rankingQ = `UPDATE rankings SET disqualified = $3 WHERE contest_id = $1 AND user_id = $2`
+
+	if _, err := tx.ExecContext(ctx, rankingQ, d.ContestID, d.UserID, d.Action == models.DisqualifyAction); err != nil {
+		log.Printf("disqualification-store: ranking update failed: %v", err)
+		return fmt.Errorf("update ranking: %w", err)
+	}
+
+	const historyQ = `
+		INSERT INTO disqualifications (id, contest_id, user_id, action, reason, evidence, admin_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = tx.ExecContext(ctx, historyQ,
+		d.ID,
+		d.ContestID,
+		d.UserID,
+		d.Action,
+		d.Reason,
+		pq.Array(d.Evidence),
+		d.AdminID,
+		d.CreatedAt,
+	)
+	if err != nil {
+		log.Printf("disqualification-store: insert failed: %v", err)
+		return fmt.Errorf("insert disqualification: %w", err)
+	}
+
+	return updateTeamRankingFlags(ctx, tx, d.ContestID, d.UserID)
+}
+
+func (s *DisqualificationStore) ListDisqualifications(ctx context.Context, contestID string, userID string) ([]models.Disqualification, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("disqualification store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, contest_id, user_id, action, reason, evidence, admin_id, created_at
+		FROM disqualifications
+		WHERE contest_id = $1 AND user_id = $2
+		ORDER BY seq DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID, userID)
+	if err != nil {
+		log.Printf("disqualification-store: query failed: %v", err)
+		return nil, fmt.Errorf("query disqualifications: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]models.Disqualification, 0)
+	for rows.Next() {
+		d, err := scanDisqualification(rows)
+		if err != nil {
+			log.Printf("disqualification-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan disqualification row: %w", err)
+		}
+		history = append(history, *d)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("disqualification-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetLatestDisqualification returns the most recent history entry, which reflects
+// the current disqualification state of the user
+func (s *DisqualificationStore) GetLatestDisqualification(ctx context.Context, contestID string, userID string) (*models.Disqualification, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("disqualification store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, contest_id, user_id, action, reason, evidence, admin_id, created_at
+		FROM disqualifications
+		WHERE contest_id = $1 AND user_id = $2
+		ORDER BY seq DESC
+		LIMIT 1
+	`
+
+	d, err := scanDisqualification(s.db.QueryRowContext(ctx, q, contestID, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ErrNotFound
+		}
+		log.Printf("disqualification-store: query failed: %v", err)
+		return nil, fmt.Errorf("query disqualification: %w", err)
+	}
+
+	return d, nil
+}
+
+func (s *DisqualificationStore) CreateAppeal(ctx context.Context, a *models.DisqualificationAppeal) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("disqualification store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("disqualification-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockAppeals(ctx, tx, a.ContestID, a.UserID); err != nil {
+		return err
+	}
+
+	const q = `
+		INSERT INTO disqualification_appeals (id, disqualification_id, contest_id, user_id, message, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (contest_id, user_id) WHERE status = 'pending' DO NOTHING
+	`
+
+	res, err := tx.ExecContext(ctx, q,
+		a.ID,
+		a.DisqualificationID,
+		a.ContestID,
+		a.UserID,
+		a.Message,
+		a.Status,
+		a.CreatedAt,
+	)
+	if err != nil {
+		log.Printf("disqualification-store: insert appeal failed: %v", err)
+		return fmt.Errorf("insert appeal: %w", err)
+	}
+
+	// If rows affected is 0, then an appeal is already pending
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("disqualification-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.AppealAlreadyPendingError
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("disqualification-store: commit failed: %v", err)
+		return fmt.Errorf("commit appeal: %w", err)
+	}
+
+	return nil
+}
+
+// lockAppeals serializes filing appeals with recording disqualifications of the same user
+func lockAppeals(ctx context.Context, tx *sql.Tx, contestID string, userID string) error {
+	const q = `SELECT pg_advisory_xact_lock(hashtext('appeals:' || $1 || ':' || $2))`
+
+	if _, err := tx.ExecContext(ctx, q, contestID, userID); err != nil {
+		log.Printf("disqualification-store: lock failed: %v", err)
+		return fmt.Errorf("lock appeals: %w", err)
+	}
+	return nil
+}
+
+func (s *DisqualificationStore) GetLatestAppeal(ctx context.Context, disqualificationID string) (*models.DisqualificationAppeal, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("disqualification store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, disqualification_id, contest_id, user_id, message, status, response, resolved_by, created_at, resolved_at
+		FROM disqualification_appeals
+		WHERE disqualification_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	a, err := scanAppeal(s.db.QueryRowContext(ctx, q, disqualificationID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.AppealNotFoundError
+		}
+		log.Printf("disqualification-store: query failed: %v", err)
+		return nil, fmt.Errorf("query appeal: %w", err)
+	}
+
+	return a, nil
+}
+
+func (s *DisqualificationStore) GetAppeal(ctx context.Context, contestID string, appealID string) (*models.DisqualificationAppeal, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("disqualification store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, disqualification_id, contest_id, user_id, message, status, response, resolved_by, created_at, resolved_at
+		FROM disqualification_appeals
+		WHERE id = $1 AND contest_id = $2
+	`
+
+	a, err := scanAppeal(s.db.QueryRowContext(ctx, q, appealID, contestID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.AppealNotFoundError
+		}
+		log.Printf("disqualification-store: query failed: %v", err)
+		return nil, fmt.Errorf("query appeal: %w", err)
+	}
+
+	return a, nil
+}
+
+func (s *DisqualificationStore) ListAppeals(ctx context.Context, contestID string, status models.AppealStatus, page int) ([]models.DisqualificationAppeal, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("disqualification store: db is not initialized")
+	}
+
+	const pageSize = 20
+	page = max(0, page)
+	offset := page * pageSize
+
+	const q = `
+		SELECT id, disqualification_id, contest_id, user_id, message, status, response, resolved_by, created_at, resolved_at
+		FROM disqualification_appeals
+		WHERE contest_id = $1 AND ($2 = '' OR status::TEXT = $2)
+		ORDER BY created_at ASC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID, string(status), pageSize, offset)
+	if err != nil {
+		log.Printf("disqualification-store: query failed: %v", err)
+		return nil, fmt.Errorf("query appeals: %w", err)
+	}
+	defer rows.Close()
+
+	appeals := make([]models.DisqualificationAppeal, 0)
+	for rows.Next() {
+		a, err := scanAppeal(rows)
+		if err != nil {
+			log.Printf("disqualification-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan appeal row: %w", err)
+		}
+		appeals = append(appeals, *a)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("disqualification-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return appeals, nil
+}
+
+// ResolveAppeal closes a pending appeal. When reinstatement is provided it is
+// recorded in the disqualification history within the same transaction.
+func (s *DisqualificationStore) ResolveAppeal(ctx context.Context, a *models.DisqualificationAppeal, reinstatement *models.Disqualification) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("disqualification store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("disqualification-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockAppeals(ctx, tx, a.ContestID, a.UserID); err != nil {
+		return err
+	}
+
+	const q = `
+		UPDATE disqualification_appeals
+		SET status = $3, response = $4, resolved_by = $5, resolved_at = $6
+		WHERE id = $1 AND contest_id = $2 AND status = 'pending'
+	`
+
+	res, err := tx.ExecContext(ctx, q, a.ID, a.ContestID, a.Status, a.Response, a.ResolvedBy, a.ResolvedAt)
+	if err != nil {
+		log.Printf("disqualification-store: update appeal failed: %v", err)
+		return fmt.Errorf("update appeal: %w", err)
+	}
+
+	// If rows affected is 0, then the appeal was resolved concurrently
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("disqualification-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.AppealAlreadyResolvedError
+	}
+
+	if reinstatement != nil {
+		if err := recordDisqualificationTx(ctx, tx, reinstatement); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("disqualification-store: commit failed: %v", err)
+		return fmt.Errorf("commit appeal: %w", err)
+	}
+
+	return nil
+}
+
+func scanDisqualification(row rowScanner) (*models.Disqualification, error) {
+	var d models.Disqualification
+	var adminID sql.NullString
+
+	if err := row.Scan(&d.ID, &d.ContestID, &d.UserID, &d.Action, &d.Reason, pq.Array(&d.Evidence), &adminID, &d.CreatedAt); err != nil {
+		return nil, err
+	}
+	d.AdminID = adminID.String
+
+	return &d, nil
+}
+
+func scanAppeal(row rowScanner) (*models.DisqualificationAppeal, error) {
+	var a models.DisqualificationAppeal
+	var response, resolvedBy sql.NullString
+	var resolvedAt sql.NullInt64
+
+	if err := row.Scan(&a.ID, &a.DisqualificationID, &a.ContestID, &a.UserID, &a.Message, &a.Status, &response, &resolvedBy, &a.CreatedAt, &resolvedAt); err != nil {
+		return nil, err
+	}
+	a.Response = response.String
+	a.ResolvedBy = resolvedBy.String
+	a.ResolvedAt = resolvedAt.Int64
+
+	return &a, nil
+}
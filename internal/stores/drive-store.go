@@ -0,0 +1,414 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+type DriveStore struct {
+	db *sql.DB
+}
+
+func NewDriveStore(db *sql.DB) *DriveStore {
+	return &DriveStore{
+		db: db,
+	}
+}
+
+const driveColumns = `id, name, description, created_by, created_at`
+
+func scanDrive(row rowScanner, d *models.RecruitmentDrive) error {
+	var createdBy sql.NullString
+
+	if err := row.Scan(&d.ID, &d.Name, &d.Description, &createdBy, &d.CreatedAt); err != nil {
+		return err
+	}
+
+	d.CreatedBy = createdBy.String
+	return nil
+}
+
+// driveRoundColumns reads a round joined with its contest, aliased dr and c
+const driveRoundColumns = `dr.contest_id, dr.position, c.name, c.start_time, c.end_time, c.status`
+
+func scanDriveRound(row rowScanner, r *models.DriveRound, extra ...any) error {
+	return row.Scan(append([]any{&r.ContestID, &r.Position, &r.Name, &r.StartTime, &r.EndTime, &r.Status}, extra...)...)
+}
+
+// ListDrives lists the drives without their rounds, newest first
+func (s *DriveStore) ListDrives(ctx context.Context, page int) ([]models.RecruitmentDrive, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("drive store: db is not initialized")
+	}
+
+	const pageSize = 20
+	page = max(0, page)
+	offset := page * pageSize
+
+	const q = `
+		SELECT ` + driveColumns + `
+		FROM recruitment_drives
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, pageSize, offset)
+	if err != nil {
+		log.Printf("drive-store: query failed: %v", err)
+		return nil, fmt.Errorf("query drives: %w", err)
+	}
+	defer rows.Close()
+
+	drives := make([]models.RecruitmentDrive, 0)
+	for rows.Next() {
+		var d models.RecruitmentDrive
+		if err := scanDrive(rows, &d); err != nil {
+			log.Printf("drive-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan drive row: %w", err)
+		}
+		drives = append(drives, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("drive-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return drives, nil
+}
+
+// GetDrive returns a drive with its rounds in order
+func (s *DriveStore) GetDrive(ctx context.Context, driveID string) (*models.RecruitmentDrive, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("drive store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + driveColumns + `
+		FROM recruitment_drives
+		WHERE id = $1
+	`
+
+	var d models.RecruitmentDrive
+	if err := scanDrive(s.db.QueryRowContext(ctx, q, driveID), &d); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.DriveNotFoundError
+		}
+		log.Printf("drive-store: query failed: %v", err)
+		return nil, fmt.Errorf("query drive: %w", err)
+	}
+
+	const roundsQ = `
+		SELECT ` + driveRoundColumns + `
+		FROM drive_rounds dr
+		JOIN contests c ON c.id = dr.contest_id
+		WHERE dr.drive_id = $1
+		ORDER BY dr.position ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, roundsQ, driveID)
+	if err != nil {
+		log.Printf("drive-store: query failed: %v", err)
+		return nil, fmt.Errorf("query drive rounds: %w", err)
+	}
+	defer rows.Close()
+
+	d.Rounds = make([]models.DriveRound, 0)
+	for rows.Next() {
+		var r models.DriveRound
+		if err := scanDriveRound(rows, &r); err != nil {
+			log.Printf("drive-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan drive round row: %w", err)
+		}
+		d.Rounds = append(d.Rounds, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("drive-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return &d, nil
+}
+
+func (s *DriveStore) CreateDrive(ctx context.Context, d *models.RecruitmentDrive) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("drive store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO recruitment_drives (` + driveColumns + `)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+	`
+
+	_, err := s.db.ExecContext(ctx, q, d.ID, d.Name, d.Description, d.CreatedBy, d.CreatedAt)
+	if err != nil {
+		log.Printf("drive-store: insert failed: %v", err)
+		return fmt.Errorf("insert drive: %w", err)
+	}
+
+	return nil
+}
+
+func (s *DriveStore) UpdateDrive(ctx context.Context, d *models.RecruitmentDrive) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("drive store: db is not initialized")
+	}
+
+	const q = `
+		UPDATE recruitment_drives
+		SET name = $2, description = $3
+		WHERE id = $1
+	`
+
+	res, err := s.db.ExecContext(ctx, q, d.ID, d.Name, d.Description)
+	if err != nil {
+		log.Printf("drive-store: update failed: %v", err)
+		return fmt.Errorf("update drive: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("drive-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.DriveNotFoundError
+	}
+
+	return nil
+}
+
+// DeleteDrive deletes a drive, its contests are kept
+func (s *DriveStore) DeleteDrive(ctx context.Context, driveID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("drive store: db is not initialized")
+	}
+
+	const q = `DELETE FROM recruitment_drives WHERE id = $1`
+
+	res, err := s.db.ExecContext(ctx, q, driveID)
+	if err != nil {
+		log.Printf("drive-store: delete failed: %v", err)
+		return fmt.Errorf("delete drive: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("drive-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.DriveNotFoundError
+	}
+
+	return nil
+}
+
+// SetRounds replaces the rounds of a drive with the contests, in order
+func (s *DriveStore) SetRounds(ctx context.Context, driveID string, contestIDs []string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("drive store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("drive-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const lockQ = `SELECT id FROM recruitment_drives WHERE id = $1 FOR UPDATE`
+
+	if err := tx.QueryRowContext(ctx, lockQ, driveID).Scan(&driveID); err != nil {
+		if err == sql.ErrNoRows {
+			return common.DriveNotFoundError
+		}
+		log.Printf("drive-store: query failed: %v", err)
+		return fmt.Errorf("lock drive: %w", err)
+	}
+
+	seen := make(map[string]bool, len(contestIDs))
+	for _, id := range contestIDs {
+		if seen[id] {
+			return common.DuplicateRoundError
+		}
+		seen[id] = true
+	}
+
+	const countQ = `SELECT COUNT(*) FROM contests WHERE id = ANY($1) AND NOT is_template`
+
+	var found int
+	if err := tx.QueryRowContext(ctx, countQ, pq.Array(contestIDs)).Scan(&found); err != nil {
+		log.Printf("drive-store: query failed: %v", err)
+		return fmt.Errorf("count contests: %w", err)
+	}
+
+	if found != len(contestIDs) {
+		return common.ContestNotFoundError
+	}
+
+	const otherQ = `SELECT EXISTS (SELECT 1 FROM drive_rounds WHERE contest_id = ANY($1) AND drive_id <> $2)`
+
+	var inOther bool
+	if err := tx.QueryRowContext(ctx, otherQ, pq.Array(contestIDs), driveID).Scan(&inOther); err != nil {
+		log.Printf("drive-store: query failed: %v", err)
+		return fmt.Errorf("query drive rounds: %w", err)
+	}
+
+	if inOther {
+		return common.ContestInOtherDriveError
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM drive_rounds WHERE drive_id = $1`, driveID); err != nil {
+		log.Printf("drive-store: delete failed: %v", err)
+		return fmt.Errorf("delete drive rounds: %w", err)
+	}
+
+	const insertQ = `
+		INSERT INTO drive_rounds (drive_id, contest_id, position)
+		SELECT $1, contest_id, position
+		FROM unnest($2::TEXT[]) WITH ORDINALITY AS r(contest_id, position)
+	`
+
+	if _, err := tx.ExecContext(ctx, insertQ, driveID, pq.Array(contestIDs)); err != nil {
+		log.Printf("drive-store: insert failed: %v", err)
+		return fmt.Errorf("insert drive rounds: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("drive-store: commit failed: %v", err)
+		return fmt.Errorf("commit drive rounds: %w", err)
+	}
+
+	return nil
+}
+
+// GetProgress returns how far the user got in each round of a drive, in order.
+// Users attempted a round once they started their attempt or made a ranked submission.
+func (s *DriveStore) GetProgress(ctx context.Context, driveID string, userID string) ([]models.DriveRoundProgress, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("drive store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + driveRoundColumns + `,
+			EXISTS (SELECT 1 FROM contest_registrations reg WHERE reg.contest_id = dr.contest_id AND reg.user_id = $2),
+			EXISTS (SELECT 1 FROM contest_registrations reg WHERE reg.contest_id = dr.contest_id AND reg.user_id = $2 AND reg.started_at IS NOT NULL)
+				OR EXISTS (SELECT 1 FROM submissions sub WHERE sub.contest_id = dr.contest_id AND sub.user_id = $2 AND NOT sub.practice),
+			COALESCE(r.shortlisted, FALSE)
+		FROM drive_rounds dr
+		JOIN contests c ON c.id = dr.contest_id
+		LEFT JOIN rankings r ON r.contest_id = dr.contest_id AND r.user_id = $2
+		WHERE dr.drive_id = $1
+		ORDER BY dr.position ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, driveID, userID)
+	if err != nil {
+		log.Printf("drive-store: query failed: %v", err)
+		return nil, fmt.Errorf("query drive progress: %w", err)
+	}
+	defer rows.Close()
+
+	progress := make([]models.DriveRoundProgress, 0)
+	for rows.Next() {
+		var p models.DriveRoundProgress
+		if err := scanDriveRound(rows, &p.DriveRound, &p.Registered, &p.Attempted, &p.Shortlisted); err != nil {
+			log.Printf("drive-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan drive progress row: %w", err)
+		}
+
+		p.Unlocked = len(progress) == 0 || progress[len(progress)-1].Shortlisted
+		progress = append(progress, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("drive-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return progress, nil
+}
+
+// GetFunnel counts the users registered, attempting and shortlisted in each round of a drive, in order
+func (s *DriveStore) GetFunnel(ctx context.Context, driveID string) ([]models.DriveFunnelRound, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("drive store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + driveRoundColumns + `,
+			(SELECT COUNT(*) FROM contest_registrations reg WHERE reg.contest_id = dr.contest_id),
+			(SELECT COUNT(*) FROM (
+				SELECT user_id FROM contest_registrations reg WHERE reg.contest_id = dr.contest_id AND reg.started_at IS NOT NULL
+				UNION
+				SELECT user_id FROM submissions sub WHERE sub.contest_id = dr.contest_id AND NOT sub.practice
+			) attempted),
+			(SELECT COUNT(*) FROM rankings r WHERE r.contest_id = dr.contest_id AND r.shortlisted)
+		FROM drive_rounds dr
+		JOIN contests c ON c.id = dr.contest_id
+		WHERE dr.drive_id = $1
+		ORDER BY dr.position ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, driveID)
+	if err != nil {
+		log.Printf("drive-store: query failed: %v", err)
+		return nil, fmt.Errorf("query drive funnel: %w", err)
+	}
+	defer rows.Close()
+
+	funnel := make([]models.DriveFunnelRound, 0)
+	for rows.Next() {
+		var f models.DriveFunnelRound
+		if err := scanDriveRound(rows, &f.DriveRound, &f.Registered, &f.Attempted, &f.Shortlisted); err != nil {
+			log.Printf("drive-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan drive funnel row: %w", err)
+		}
+		funnel = append(funnel, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("drive-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return funnel, nil
+}
+
+// IsPromoted reports whether the user was shortlisted in the round before the contest.
+// Contests outside drives and first rounds are open to everyone.
+func (s *DriveStore) IsPromoted(ctx context.Context, contestID string, userID string) (bool, error) {
+	if s == nil || s.db == nil {
+		return false, fmt.Errorf("drive store: db is not initialized")
+	}
+
+	const q = `
+		SELECT prev.contest_id, COALESCE(r.shortlisted, FALSE)
+		FROM drive_rounds cur
+		JOIN drive_rounds prev ON prev.drive_id = cur.drive_id AND prev.position = cur.position - 1
+		LEFT JOIN rankings r ON r.contest_id = prev.contest_id AND r.user_id = $2
+		WHERE cur.contest_id = $1
+	`
+
+	var previousID string
+	var shortlisted bool
+	if err := s.db.QueryRowContext(ctx, q, contestID, userID).Scan(&previousID, &shortlisted); err != nil {
+		if err == sql.ErrNoRows {
+			return true, nil
+		}
+		log.Printf("drive-store: query failed: %v", err)
+		return false, fmt.Errorf("query promotion: %w", err)
+	}
+
+	return shortlisted, nil
+}
@@ -0,0 +1,173 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+type EditorialStore struct {
+	db *sql.DB
+}
+
+func NewEditorialStore(db *sql.DB) *EditorialStore {
+	return &EditorialStore{
+		db: db,
+	}
+}
+
+func (s *EditorialStore) GetEditorial(ctx context.Context, contestID string, problemID string) (*models.ProblemEditorial, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("editorial store: db is not initialized")
+	}
+
+	const q = `
+		SELECT contest_id, problem_id, content, updated_at
+		FROM problem_editorials
+		WHERE contest_id = $1 AND problem_id = $2
+	`
+
+	var e models.ProblemEditorial
+	err := s.db.QueryRowContext(ctx, q, contestID, problemID).Scan(&e.ContestID, &e.ProblemID, &e.Content, &e.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.EditorialNotFoundError
+		}
+		log.Printf("editorial-store: query failed: %v", err)
+		return nil, fmt.Errorf("query editorial: %w", err)
+	}
+
+	return &e, nil
+}
+
+func (s *EditorialStore) UpsertEditorial(ctx context.Context, e *models.ProblemEditorial) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("editorial store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO problem_editorials (contest_id, problem_id, content, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (contest_id, problem_id) DO UPDATE
+		SET content = EXCLUDED.content, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := s.db.ExecContext(ctx, q, e.ContestID, e.ProblemID, e.Content, e.UpdatedAt)
+	if err != nil {
+		log.Printf("editorial-store: upsert failed: %v", err)
+		return fmt.Errorf("upsert editorial: %w", err)
+	}
+
+	return nil
+}
+
+func (s *EditorialStore) DeleteEditorial(ctx context.Context, contestID string, problemID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("editorial store: db is not initialized")
+	}
+
+	const q = `DELETE FROM problem_editorials WHERE contest_id = $1 AND problem_id = $2`
+
+	res, err := s.db.ExecContext(ctx, q, contestID, problemID)
+	if err != nil {
+		log.Printf("editorial-store: delete failed: %v", err)
+		return fmt.Errorf("delete editorial: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("editorial-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.EditorialNotFoundError
+	}
+
+	return nil
+}
+
+func (s *EditorialStore) ListSolutions(ctx context.Context, contestID string, problemID string) ([]models.ReferenceSolution, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("editorial store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, contest_id, problem_id, language, code, explanation, created_at
+		FROM problem_solutions
+		WHERE contest_id = $1 AND problem_id = $2
+		ORDER BY created_at
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID, problemID)
+	if err != nil {
+		log.Printf("editorial-store: query failed: %v", err)
+		return nil, fmt.Errorf("query solutions: %w", err)
+	}
+	defer rows.Close()
+
+	solutions := make([]models.ReferenceSolution, 0)
+	for rows.Next() {
+		var sol models.ReferenceSolution
+		if err := rows.Scan(&sol.ID, &sol.ContestID, &sol.ProblemID, &sol.Language, &sol.Code, &sol.Explanation, &sol.CreatedAt); err != nil {
+			log.Printf("editorial-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan solution row: %w", err)
+		}
+		solutions = append(solutions, sol)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("editorial-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return solutions, nil
+}
+
+func (s *EditorialStore) CreateSolution(ctx context.Context, sol *models.ReferenceSolution) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("editorial store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO problem_solutions (id, contest_id, problem_id, language, code, explanation, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := s.db.ExecContext(ctx, q, sol.ID, sol.ContestID, sol.ProblemID, sol.Language, sol.Code, sol.Explanation, sol.CreatedAt)
+	if err != nil {
+		log.Printf("editorial-store: insert failed: %v", err)
+		return fmt.Errorf("insert solution: %w", err)
+	}
+
+	return nil
+}
+
+func (s *EditorialStore) DeleteSolution(ctx context.Context, contestID string, problemID string, solutionID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("editorial store: db is not initialized")
+	}
+
+	const q = `DELETE FROM problem_solutions WHERE id = $1 AND contest_id = $2 AND problem_id = $3`
+
+	res, err := s.db.ExecContext(ctx, q, solutionID, contestID, problemID)
+	if err != nil {
+		log.Printf("editorial-store: delete failed: %v", err)
+		return fmt.Errorf("delete solution: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("editorial-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.SolutionNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,376 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+type InterviewStore struct {
+	db *sql.DB
+}
+
+func NewInterviewStore(db *sql.DB) *InterviewStore {
+	return &InterviewStore{
+		db: db,
+	}
+}
+
+// interviewColumns reads an interview aliased i, with its interviewers
+const interviewColumns = `i.id, i.drive_id, i.user_id, i.title, i.scheduled_at, i.duration, i.location, i.created_by, i.created_at, i.updated_at,
+	ARRAY(SELECT ii.admin_id FROM interview_interviewers ii WHERE ii.interview_id = i.id ORDER BY ii.admin_id)`
+
+func scanInterview(row rowScanner, i *models.Interview) error {
+	var createdBy sql.NullString
+
+	if err := row.Scan(
+		&i.ID,
+		&i.DriveID,
+		&i.UserID,
+		&i.Title,
+		&i.ScheduledAt,
+		&i.Duration,
+		&i.Location,
+		&createdBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		pq.Array(&i.Interviewers),
+	); err != nil {
+		return err
+	}
+
+	i.CreatedBy = createdBy.String
+	if i.Interviewers == nil {
+		i.Interviewers = []string{}
+	}
+	return nil
+}
+
+// ListInterviews lists the interviews of a drive by time, only those of the user unless userID is empty
+func (s *InterviewStore) ListInterviews(ctx context.Context, driveID string, userID string) ([]models.Interview, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("interview store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + interviewColumns + `
+		FROM interviews i
+		WHERE i.drive_id = $1 AND ($2 = '' OR i.user_id = $2)
+		ORDER BY i.scheduled_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, driveID, userID)
+	if err != nil {
+		log.Printf("interview-store: query failed: %v", err)
+		return nil, fmt.Errorf("query interviews: %w", err)
+	}
+	defer rows.Close()
+
+	interviews := make([]models.Interview, 0)
+	for rows.Next() {
+		var i models.Interview
+		if err := scanInterview(rows, &i); err != nil {
+			log.Printf("interview-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan interview row: %w", err)
+		}
+		interviews = append(interviews, i)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("interview-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return interviews, nil
+}
+
+func (s *InterviewStore) GetInterview(ctx context.Context, driveID string, interviewID string) (*models.Interview, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("interview store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + interviewColumns + `
+		FROM interviews i
+		WHERE i.id = $1 AND i.drive_id = $2
+	`
+
+	var i models.Interview
+	if err := scanInterview(s.db.QueryRowContext(ctx, q, interviewID, driveID), &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.InterviewNotFoundError
+		}
+		log.Printf("interview-store: query failed: %v", err)
+		return nil, fmt.Errorf("query interview: %w", err)
+	}
+
+	return &i, nil
+}
+
+func (s *InterviewStore) CreateInterview(ctx context.Context, i *models.Interview) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("interview store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("interview-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const q = `
+		INSERT INTO interviews (id, drive_id, user_id, title, scheduled_at, duration, location, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10)
+	`
+
+	_, err = tx.ExecContext(ctx, q, i.ID, i.DriveID, i.UserID, i.Title, i.ScheduledAt, i.Duration, i.Location, i.CreatedBy, i.CreatedAt, i.UpdatedAt)
+	if err != nil {
+		log.Printf("interview-store: insert failed: %v", err)
+		return fmt.Errorf("insert interview: %w", err)
+	}
+
+	if err := setInterviewers(ctx, tx, i.ID, i.Interviewers); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("interview-store: commit failed: %v", err)
+		return fmt.Errorf("commit interview: %w", err)
+	}
+
+	return nil
+}
+
+func (s *InterviewStore) UpdateInterview(ctx context.Context, i *models.Interview) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("interview store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("interview-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const q = `
+		UPDATE interviews
+		SET user_id = $3, title = $4, scheduled_at = $5, duration = $6, location = $7, updated_at = $8
+		WHERE id = $1 AND drive_id = $2
+	`
+
+	res, err := tx.ExecContext(ctx, q, i.ID, i.DriveID, i.UserID, i.Title, i.ScheduledAt, i.Duration, i.Location, i.UpdatedAt)
+	if err != nil {
+		log.Printf("interview-store: update failed: %v", err)
+		return fmt.Errorf("update interview: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("interview-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.InterviewNotFoundError
+	}
+
+	if err := setInterviewers(ctx, tx, i.ID, i.Interviewers); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("interview-store: commit failed: %v", err)
+		return fmt.Errorf("commit interview: %w", err)
+	}
+
+	return nil
+}
+
+// setInterviewers replaces the interviewers assigned to an interview
+func setInterviewers(ctx context.Context, db execer, interviewID string, adminIDs []string) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM interview_interviewers WHERE interview_id = $1`, interviewID); err != nil {
+		log.Printf("interview-store: delete failed: %v", err)
+		return fmt.Errorf("delete interviewers: %w", err)
+	}
+
+	const q = `
+		INSERT INTO interview_interviewers (interview_id, admin_id)
+		SELECT $1, unnest($2::TEXT[])
+	`
+
+	if _, err := db.ExecContext(ctx, q, interviewID, pq.Array(adminIDs)); err != nil {
+		log.Printf("interview-store: insert failed: %v", err)
+		return fmt.Errorf("insert interviewers: %w", err)
+	}
+
+	return nil
+}
+
+func (s *InterviewStore) DeleteInterview(ctx context.Context, driveID string, interviewID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("interview store: db is not initialized")
+	}
+
+	const q = `DELETE FROM interviews WHERE id = $1 AND drive_id = $2`
+
+	res, err := s.db.ExecContext(ctx, q, interviewID, driveID)
+	if err != nil {
+		log.Printf("interview-store: delete failed: %v", err)
+		return fmt.Errorf("delete interview: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("interview-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.InterviewNotFoundError
+	}
+
+	return nil
+}
+
+const feedbackColumns = `interview_id, interviewer_id, problem_solving, coding, communication, comments, submitted_at, updated_at`
+
+func (s *InterviewStore) ListFeedback(ctx context.Context, interviewID string) ([]models.InterviewFeedback, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("interview store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + feedbackColumns + `
+		FROM interview_feedback
+		WHERE interview_id = $1
+		ORDER BY submitted_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, interviewID)
+	if err != nil {
+		log.Printf("interview-store: query failed: %v", err)
+		return nil, fmt.Errorf("query feedback: %w", err)
+	}
+	defer rows.Close()
+
+	feedback := make([]models.InterviewFeedback, 0)
+	for rows.Next() {
+		var f models.InterviewFeedback
+		if err := rows.Scan(&f.InterviewID, &f.InterviewerID, &f.ProblemSolving, &f.Coding, &f.Communication, &f.Comments, &f.SubmittedAt, &f.UpdatedAt); err != nil {
+			log.Printf("interview-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan feedback row: %w", err)
+		}
+		feedback = append(feedback, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("interview-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return feedback, nil
+}
+
+// UpsertFeedback records an interviewer's feedback, replacing what they submitted before
+func (s *InterviewStore) UpsertFeedback(ctx context.Context, f *models.InterviewFeedback) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("interview store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO interview_feedback (` + feedbackColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (interview_id, interviewer_id) DO UPDATE
+		SET problem_solving = EXCLUDED.problem_solving,
+			coding = EXCLUDED.coding,
+			communication = EXCLUDED.communication,
+			comments = EXCLUDED.comments,
+			updated_at = EXCLUDED.updated_at
+		RETURNING submitted_at
+	`
+
+	err := s.db.QueryRowContext(ctx, q,
+		f.InterviewID,
+		f.InterviewerID,
+		f.ProblemSolving,
+		f.Coding,
+		f.Communication,
+		f.Comments,
+		f.SubmittedAt,
+		f.UpdatedAt,
+	).Scan(&f.SubmittedAt)
+	if err != nil {
+		log.Printf("interview-store: upsert failed: %v", err)
+		return fmt.Errorf("upsert feedback: %w", err)
+	}
+
+	return nil
+}
+
+func (s *InterviewStore) ListDecisions(ctx context.Context, driveID string) ([]models.CandidateDecision, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("interview store: db is not initialized")
+	}
+
+	const q = `
+		SELECT drive_id, user_id, decision, notes, decided_by, decided_at
+		FROM drive_decisions
+		WHERE drive_id = $1
+		ORDER BY decided_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, driveID)
+	if err != nil {
+		log.Printf("interview-store: query failed: %v", err)
+		return nil, fmt.Errorf("query decisions: %w", err)
+	}
+	defer rows.Close()
+
+	decisions := make([]models.CandidateDecision, 0)
+	for rows.Next() {
+		var d models.CandidateDecision
+		var decidedBy sql.NullString
+		if err := rows.Scan(&d.DriveID, &d.UserID, &d.Decision, &d.Notes, &decidedBy, &d.DecidedAt); err != nil {
+			log.Printf("interview-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan decision row: %w", err)
+		}
+		d.DecidedBy = decidedBy.String
+		decisions = append(decisions, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("interview-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return decisions, nil
+}
+
+// SetDecision records the final decision on a candidate, replacing any previous one
+func (s *InterviewStore) SetDecision(ctx context.Context, d *models.CandidateDecision) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("interview store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO drive_decisions (drive_id, user_id, decision, notes, decided_by, decided_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+		ON CONFLICT (drive_id, user_id) DO UPDATE
+		SET decision = EXCLUDED.decision, notes = EXCLUDED.notes, decided_by = EXCLUDED.decided_by, decided_at = EXCLUDED.decided_at
+	`
+
+	_, err := s.db.ExecContext(ctx, q, d.DriveID, d.UserID, d.Decision, d.Notes, d.DecidedBy, d.DecidedAt)
+	if err != nil {
+		log.Printf("interview-store: upsert failed: %v", err)
+		return fmt.Errorf("set decision: %w", err)
+	}
+
+	return nil
+}
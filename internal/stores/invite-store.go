@@ -0,0 +1,275 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+type InviteStore struct {
+	db *sql.DB
+}
+
+func NewInviteStore(db *sql.DB) *InviteStore {
+	return &InviteStore{
+		db: db,
+	}
+}
+
+const inviteCodeColumns = `code, contest_id, max_uses, uses, expires_at, created_by, created_at`
+
+func scanInviteCode(row rowScanner, i *models.InviteCode) error {
+	var createdBy sql.NullString
+
+	if err := row.Scan(&i.Code, &i.ContestID, &i.MaxUses, &i.Uses, &i.ExpiresAt, &createdBy, &i.CreatedAt); err != nil {
+		return err
+	}
+
+	i.CreatedBy = createdBy.String
+	return nil
+}
+
+// ListInviteCodes lists the invite codes of a contest, newest first
+func (s *InviteStore) ListInviteCodes(ctx context.Context, contestID string) ([]models.InviteCode, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("invite store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + inviteCodeColumns + `
+		FROM contest_invite_codes
+		WHERE contest_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID)
+	if err != nil {
+		log.Printf("invite-store: query failed: %v", err)
+		return nil, fmt.Errorf("query invite codes: %w", err)
+	}
+	defer rows.Close()
+
+	codes := make([]models.InviteCode, 0)
+	for rows.Next() {
+		var i models.InviteCode
+		if err := scanInviteCode(rows, &i); err != nil {
+			log.Printf("invite-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan invite code row: %w", err)
+		}
+		codes = append(codes, i)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("invite-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (s *InviteStore) CreateInviteCode(ctx context.Context, i *models.InviteCode) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("invite store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO contest_invite_codes (` + inviteCodeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+		ON CONFLICT (code) DO NOTHING
+	`
+
+	res, err := s.db.ExecContext(ctx, q, i.Code, i.ContestID, i.MaxUses, i.Uses, i.ExpiresAt, i.CreatedBy, i.CreatedAt)
+	if err != nil {
+		log.Printf("invite-store: insert failed: %v", err)
+		return fmt.Errorf("insert invite code: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("invite-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.KeyAlreadyExistsError
+	}
+
+	return nil
+}
+
+func (s *InviteStore) DeleteInviteCode(ctx context.Context, contestID string, code string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("invite store: db is not initialized")
+	}
+
+	const q = `DELETE FROM contest_invite_codes WHERE code = $1 AND contest_id = $2`
+
+	res, err := s.db.ExecContext(ctx, q, code, contestID)
+	if err != nil {
+		log.Printf("invite-store: delete failed: %v", err)
+		return fmt.Errorf("delete invite code: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("invite-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.InviteCodeNotFoundError
+	}
+
+	return nil
+}
+
+// CheckInviteCode checks that the user can redeem the code without redeeming it. Codes the
+// user already redeemed stay valid for them.
+func (s *InviteStore) CheckInviteCode(ctx context.Context, contestID string, code string, userID string, now int64) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("invite store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + inviteCodeColumns + `
+		FROM contest_invite_codes
+		WHERE code = $1 AND contest_id = $2
+	`
+
+	var invite models.InviteCode
+	if err := scanInviteCode(s.db.QueryRowContext(ctx, q, code, contestID), &invite); err != nil {
+		if err == sql.ErrNoRows {
+			return common.InviteCodeNotFoundError
+		}
+		log.Printf("invite-store: query failed: %v", err)
+		return fmt.Errorf("query invite code: %w", err)
+	}
+
+	const redeemedQ = `SELECT EXISTS (SELECT 1 FROM contest_invite_redemptions WHERE code = $1 AND user_id = $2)`
+
+	var redeemed bool
+	if err := s.db.QueryRowContext(ctx, redeemedQ, code, userID).Scan(&redeemed); err != nil {
+		log.Printf("invite-store: query failed: %v", err)
+		return fmt.Errorf("query redemption: %w", err)
+	}
+
+	if redeemed {
+		return nil
+	}
+
+	if invite.IsExpired(now) {
+		return common.InviteCodeExpiredError
+	}
+
+	if invite.IsExhausted() {
+		return common.InviteCodeExhaustedError
+	}
+
+	return nil
+}
+
+// redeemInviteCode uses up the code for the user and puts them on the contest's allow list,
+// within the caller's transaction. Redeeming a code again is a no-op, and users on the deny
+// list stay denied.
+func redeemInviteCode(ctx context.Context, tx *sql.Tx, contestID string, code string, userID string, now int64) error {
+	// Lock the code so that concurrent redemptions cannot exceed its uses
+	const lockQ = `
+		SELECT ` + inviteCodeColumns + `
+		FROM contest_invite_codes
+		WHERE code = $1 AND contest_id = $2
+		FOR UPDATE
+	`
+
+	var invite models.InviteCode
+	if err := scanInviteCode(tx.QueryRowContext(ctx, lockQ, code, contestID), &invite); err != nil {
+		if err == sql.ErrNoRows {
+			return common.InviteCodeNotFoundError
+		}
+		log.Printf("invite-store: query failed: %v", err)
+		return fmt.Errorf("query invite code: %w", err)
+	}
+
+	const redeemQ = `
+		INSERT INTO contest_invite_redemptions (code, user_id, redeemed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (code, user_id) DO NOTHING
+	`
+
+	res, err := tx.ExecContext(ctx, redeemQ, code, userID, now/1000)
+	if err != nil {
+		log.Printf("invite-store: insert failed: %v", err)
+		return fmt.Errorf("insert redemption: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("invite-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	if invite.IsExpired(now) {
+		return common.InviteCodeExpiredError
+	}
+
+	if invite.IsExhausted() {
+		return common.InviteCodeExhaustedError
+	}
+
+	const useQ = `UPDATE contest_invite_codes SET uses = uses + 1 WHERE code = $1`
+
+	if _, err := tx.ExecContext(ctx, useQ, code); err != nil {
+		log.Printf("invite-store: update failed: %v", err)
+		return fmt.Errorf("update invite code: %w", err)
+	}
+
+	const allowQ = `
+		INSERT INTO contest_access_list (contest_id, user_id, access, added_by, added_at)
+		VALUES ($1, $2, 'allow', NULL, $3)
+		ON CONFLICT (contest_id, user_id) DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, allowQ, contestID, userID, now/1000); err != nil {
+		log.Printf("invite-store: insert failed: %v", err)
+		return fmt.Errorf("insert access: %w", err)
+	}
+
+	return nil
+}
+
+// InviteShortlisted puts the users shortlisted in the source contest's rankings on the contest's
+// allow list and returns how many were added. Users already on the list keep their entry.
+func (s *InviteStore) InviteShortlisted(ctx context.Context, contestID string, sourceContestID string, adminID string, addedAt int64) (int, error) {
+	if s == nil || s.db == nil {
+		return 0, fmt.Errorf("invite store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO contest_access_list (contest_id, user_id, access, added_by, added_at)
+		SELECT $1, r.user_id, 'allow', NULLIF($3, ''), $4
+		FROM rankings r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.contest_id = $2 AND r.shortlisted
+		ON CONFLICT (contest_id, user_id) DO NOTHING
+	`
+
+	res, err := s.db.ExecContext(ctx, q, contestID, sourceContestID, adminID, addedAt)
+	if err != nil {
+		log.Printf("invite-store: insert failed: %v", err)
+		return 0, fmt.Errorf("invite shortlisted: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("invite-store: rows error %v", err)
+		return 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return int(affected), nil
+}
@@ -0,0 +1,259 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+type ProblemSetStore struct {
+	db *sql.DB
+}
+
+func NewProblemSetStore(db *sql.DB) *ProblemSetStore {
+	return &ProblemSetStore{
+		db: db,
+	}
+}
+
+func (s *ProblemSetStore) ListPools(ctx context.Context, contestID string) ([]models.ContestPool, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem set store: db is not initialized")
+	}
+
+	const q = `
+		SELECT contest_id, tag, draw
+		FROM contest_pools
+		WHERE contest_id = $1
+		ORDER BY tag
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID)
+	if err != nil {
+		log.Printf("problem-set-store: query failed: %v", err)
+		return nil, fmt.Errorf("query pools: %w", err)
+	}
+	defer rows.Close()
+
+	pools := make([]models.ContestPool, 0)
+	for rows.Next() {
+		var pool models.ContestPool
+		if err := rows.Scan(&pool.ContestID, &pool.Tag, &pool.Draw); err != nil {
+			log.Printf("problem-set-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan pool row: %w", err)
+		}
+		pools = append(pools, pool)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-set-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return pools, nil
+}
+
+func (s *ProblemSetStore) UpsertPool(ctx context.Context, pool *models.ContestPool) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem set store: db is not initialized")
+	}
+
+	if err := upsertPool(ctx, s.db, pool); err != nil {
+		log.Printf("problem-set-store: upsert pool failed: %v", err)
+		return fmt.Errorf("upsert pool: %w", err)
+	}
+
+	return nil
+}
+
+func upsertPool(ctx context.Context, db execer, pool *models.ContestPool) error {
+	const q = `
+		INSERT INTO contest_pools (contest_id, tag, draw)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (contest_id, tag) DO UPDATE SET draw = EXCLUDED.draw
+	`
+
+	_, err := db.ExecContext(ctx, q, pool.ContestID, pool.Tag, pool.Draw)
+	return err
+}
+
+func (s *ProblemSetStore) DeletePool(ctx context.Context, contestID string, tag string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem set store: db is not initialized")
+	}
+
+	const q = `DELETE FROM contest_pools WHERE contest_id = $1 AND tag = $2`
+
+	res, err := s.db.ExecContext(ctx, q, contestID, tag)
+	if err != nil {
+		log.Printf("problem-set-store: delete pool failed: %v", err)
+		return fmt.Errorf("delete pool: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("problem-set-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.PoolNotFoundError
+	}
+
+	return nil
+}
+
+// GetProblemSet returns the problems drawn for a user in presentation order, empty if none were drawn yet
+func (s *ProblemSetStore) GetProblemSet(ctx context.Context, contestID string, userID string) ([]models.ProblemSetEntry, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem set store: db is not initialized")
+	}
+
+	const q = `
+		SELECT contest_id, user_id, problem_id, position, option_order
+		FROM problem_sets
+		WHERE contest_id = $1 AND user_id = $2
+		ORDER BY position
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID, userID)
+	if err != nil {
+		log.Printf("problem-set-store: query failed: %v", err)
+		return nil, fmt.Errorf("query problem set: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]models.ProblemSetEntry, 0)
+	for rows.Next() {
+		var e models.ProblemSetEntry
+		var optionOrder pq.Int64Array
+
+		if err := rows.Scan(&e.ContestID, &e.UserID, &e.ProblemID, &e.Position, &optionOrder); err != nil {
+			log.Printf("problem-set-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan problem set row: %w", err)
+		}
+
+		for _, id := range optionOrder {
+			e.OptionOrder = append(e.OptionOrder, int(id))
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-set-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CreateProblemSet stores the problems drawn for a user. If a set was already stored,
+// for example by a concurrent request, it is kept and the new one is discarded.
+func (s *ProblemSetStore) CreateProblemSet(ctx context.Context, contestID string, userID string, entries []models.ProblemSetEntry) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem set store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("problem-set-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serialize the first draw for the same user
+	const lockQ = `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`
+	if _, err := tx.ExecContext(ctx, lockQ, contestID, userID); err != nil {
+		log.Printf("problem-set-store: lock failed: %v", err)
+		return fmt.Errorf("lock problem set: %w", err)
+	}
+
+	var exists bool
+	const existsQ = `SELECT EXISTS(SELECT 1 FROM problem_sets WHERE contest_id = $1 AND user_id = $2)`
+	if err := tx.QueryRowContext(ctx, existsQ, contestID, userID).Scan(&exists); err != nil {
+		log.Printf("problem-set-store: query failed: %v", err)
+		return fmt.Errorf("query problem set: %w", err)
+	}
+
+	if exists {
+		return nil
+	}
+
+	const q = `
+		INSERT INTO problem_sets (contest_id, user_id, problem_id, position, option_order)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	for _, e := range entries {
+		var optionOrder any
+		if len(e.OptionOrder) > 0 {
+			optionOrder = pq.Array(e.OptionOrder)
+		}
+
+		if _, err := tx.ExecContext(ctx, q, contestID, userID, e.ProblemID, e.Position, optionOrder); err != nil {
+			log.Printf("problem-set-store: insert failed: %v", err)
+			return fmt.Errorf("insert problem set: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("problem-set-store: commit failed: %v", err)
+		return fmt.Errorf("commit problem set: %w", err)
+	}
+
+	return nil
+}
+
+// AddProblemSetEntries appends problems to the end of a user's problem set, in the given order.
+// The positions of the entries are ignored and problems already in the set are skipped.
+func (s *ProblemSetStore) AddProblemSetEntries(ctx context.Context, contestID string, userID string, entries []models.ProblemSetEntry) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem set store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("problem-set-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Serialize with the draw and other additions for the same user, so positions stay unique
+	const lockQ = `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`
+	if _, err := tx.ExecContext(ctx, lockQ, contestID, userID); err != nil {
+		log.Printf("problem-set-store: lock failed: %v", err)
+		return fmt.Errorf("lock problem set: %w", err)
+	}
+
+	const q = `
+		INSERT INTO problem_sets (contest_id, user_id, problem_id, position, option_order)
+		SELECT $1, $2, $3, COALESCE(MAX(position), 0) + 1, $4
+		FROM problem_sets
+		WHERE contest_id = $1 AND user_id = $2
+		ON CONFLICT (contest_id, user_id, problem_id) DO NOTHING
+	`
+
+	for _, e := range entries {
+		var optionOrder any
+		if len(e.OptionOrder) > 0 {
+			optionOrder = pq.Array(e.OptionOrder)
+		}
+
+		if _, err := tx.ExecContext(ctx, q, contestID, userID, e.ProblemID, optionOrder); err != nil {
+			log.Printf("problem-set-store: insert failed: %v", err)
+			return fmt.Errorf("insert problem set entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("problem-set-store: commit failed: %v", err)
+		return fmt.Errorf("commit problem set entries: %w", err)
+	}
+
+	return nil
+}
@@ -22,69 +22,225 @@ func NewProblemStore(db *sql.DB) *ProblemStore {
 	}
 }
 
+// CreateProblem creates a problem together with its MCQ options and attaches it to its contest
 func (s *ProblemStore) CreateProblem(ctx context.Context, p *models.Problem) error {
 	if s == nil || s.db == nil {
 		return fmt.Errorf("problem store: db is not initialized")
 	}
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("problem-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertProblem(ctx, tx, p); err != nil {
+		log.Printf("problem-store: insert failed: %v", err)
+		return fmt.Errorf("insert problem: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("problem-store: commit failed: %v", err)
+		return fmt.Errorf("commit problem: %w", err)
+	}
+
+	return nil
+}
+
+// insertProblem adds a problem to the bank, and attaches it to p.ContestID if set
+func insertProblem(ctx context.Context, db execer, p *models.Problem) error {
+	nextOptionID := max(p.NextOptionID, 1)
+	for _, o := range p.Options {
+		nextOptionID = max(nextOptionID, o.ID+1)
+	}
+
 	const q = `
-        INSERT INTO problems (id, contest_id, name, score, type, answer)
-        VALUES ($1, $2, $3, $4, $5, $6)
+        INSERT INTO problems (id, name, description, score, type, answer, multiple_choice, negative_marks, partial_credit, tags, difficulty, next_option_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10::TEXT[], '{}'), NULLIF($11, '')::problem_difficulty, $12)
     `
-	_, err := s.db.ExecContext(ctx, q,
+	_, err := db.ExecContext(ctx, q,
 		p.ID,
-		p.ContestID,
 		p.Name,
+		p.Description,
 		p.Score,
 		p.Type,
 		pq.Array(p.Answer),
+		p.MultipleChoice,
+		p.NegativeMarks,
+		p.PartialCredit,
+		pq.Array(p.Tags),
+		p.Difficulty,
+		nextOptionID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if p.ContestID != "" {
+		if err := attachProblem(ctx, db, p, nil); err != nil {
+			return err
+		}
+	}
+
+	const optionQ = `
+        INSERT INTO problem_options (problem_id, id, position, text, image, weight)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+
+	for _, o := range p.Options {
+		if _, err := db.ExecContext(ctx, optionQ, p.ID, o.ID, o.Position, o.Text, o.Image, o.Weight); err != nil {
+			return err
+		}
+	}
+
+	for _, a := range p.Assets {
+		a.ProblemID = p.ID
+		if err := insertAsset(ctx, db, &a); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attachProblem adds a bank problem to p.ContestID with the contest specific fields of p.
+// A nil score, or the problem's own score, uses the problem's own score.
+func attachProblem(ctx context.Context, db execer, p *models.Problem, score *int) error {
+	const q = `
+        INSERT INTO contest_problems (contest_id, problem_id, score, position, label, section_id, pool)
+        SELECT $1, p.id, NULLIF($3, p.score), $4, $5, NULLIF($6, ''), NULLIF($7, '')
+        FROM problems p
+        WHERE p.id = $2
+        ON CONFLICT (contest_id, problem_id) DO NOTHING
+    `
+	res, err := db.ExecContext(ctx, q,
+		p.ContestID,
+		p.ID,
+		score,
+		p.Position,
+		p.Label,
+		p.SectionID,
+		p.Pool,
 	)
+	if err != nil {
+		return err
+	}
 
+	affected, err := res.RowsAffected()
 	if err != nil {
-		log.Printf("problem-store: insert failed: %v", err)
-		return fmt.Errorf("insert problem: %w", err)
+		return err
+	}
+
+	if affected == 0 {
+		return common.ProblemAlreadyAttachedError
 	}
 
 	return nil
 }
 
+// UpdateProblem updates a problem of a contest. Changes to the statement, answer and marking
+// apply to every contest using the problem, a changed score only overrides it in this contest.
 func (s *ProblemStore) UpdateProblem(ctx context.Context, p *models.Problem) error {
 	if s == nil || s.db == nil {
 		return fmt.Errorf("problem store: db is not initialized")
 	}
 
-	const q = `
-        UPDATE problems
-        SET name = $3,
-            score = $4,
-            type = $5,
-            answer = $6
-        WHERE id = $1 AND contest_id = $2
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("problem-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const attachmentQ = `
+        UPDATE contest_problems cp
+        SET score = NULLIF($3, p.score),
+            pool = NULLIF($4, ''),
+            label = COALESCE(NULLIF($5, ''), cp.label),
+            section_id = NULLIF($6, '')
+        FROM problems p
+        WHERE p.id = cp.problem_id AND cp.problem_id = $1 AND cp.contest_id = $2
     `
 
-	_, err := s.db.ExecContext(ctx, q,
+	res, err := tx.ExecContext(ctx, attachmentQ,
 		p.ID,
 		p.ContestID,
-		p.Name,
 		p.Score,
-		p.Type,
-		pq.Array(p.Answer),
+		p.Pool,
+		p.Label,
+		p.SectionID,
 	)
+	if err != nil {
+		log.Printf("problem-store: update failed: %v", err)
+		return fmt.Errorf("update contest problem: %w", err)
+	}
 
+	affected, err := res.RowsAffected()
 	if err != nil {
+		log.Printf("problem-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.ProblemNotFoundError
+	}
+
+	if err := updateProblem(ctx, tx, p, false); err != nil {
 		log.Printf("problem-store: update failed: %v", err)
 		return fmt.Errorf("update problem: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		log.Printf("problem-store: commit failed: %v", err)
+		return fmt.Errorf("commit problem: %w", err)
+	}
+
 	return nil
 }
 
+// updateProblem updates the fields of a problem shared by every contest using it,
+// including its own score and bank metadata when updating from the bank
+func updateProblem(ctx context.Context, db execer, p *models.Problem, fromBank bool) error {
+	const q = `
+        UPDATE problems
+        SET name = $2,
+            description = $3,
+            type = $4,
+            answer = $5,
+            multiple_choice = $6,
+            negative_marks = $7,
+            partial_credit = $8,
+            score = CASE WHEN $9 THEN $10 ELSE score END,
+            tags = CASE WHEN $9 THEN COALESCE($11::TEXT[], '{}') ELSE tags END,
+            difficulty = CASE WHEN $9 THEN NULLIF($12, '')::problem_difficulty ELSE difficulty END
+        WHERE id = $1
+    `
+
+	_, err := db.ExecContext(ctx, q,
+		p.ID,
+		p.Name,
+		p.Description,
+		p.Type,
+		pq.Array(p.Answer),
+		p.MultipleChoice,
+		p.NegativeMarks,
+		p.PartialCredit,
+		fromBank,
+		p.Score,
+		pq.Array(p.Tags),
+		p.Difficulty,
+	)
+	return err
+}
+
+// DeleteProblem detaches a problem from a contest, it stays in the bank
 func (s *ProblemStore) DeleteProblem(ctx context.Context, contestID string, problemID string) error {
 	if s == nil || s.db == nil {
 		return fmt.Errorf("problem store: db is not initialized")
 	}
 
-	const q = `DELETE FROM problems WHERE id = $1 AND contest_id = $2`
+	const q = `DELETE FROM contest_problems WHERE problem_id = $1 AND contest_id = $2`
 
 	_, err := s.db.ExecContext(ctx, q, problemID, contestID)
 
@@ -98,9 +254,12 @@ func (s *ProblemStore) DeleteProblem(ctx context.Context, contestID string, prob
 
 func (s *ProblemStore) GetProblemList(ctx context.Context, contestID string) ([]dto.ProblemOverview, error) {
 	const q = `
-		SELECT id, name, score, type
-		FROM problems
-		WHERE contest_id = $1
+		SELECT p.id, p.name, COALESCE(cp.score, p.score), p.type, cp.position, cp.label, cp.section_id, s.name
+		FROM contest_problems cp
+		JOIN problems p ON p.id = cp.problem_id
+		LEFT JOIN contest_sections s ON s.id = cp.section_id
+		WHERE cp.contest_id = $1
+		ORDER BY cp.position, p.name
 	`
 
 	rows, err := s.db.QueryContext(ctx, q, contestID)
@@ -113,12 +272,16 @@ func (s *ProblemStore) GetProblemList(ctx context.Context, contestID string) ([]
 	var problems []dto.ProblemOverview
 	for rows.Next() {
 		var p dto.ProblemOverview
+		var sectionID, section sql.NullString
 
-		if err := rows.Scan(&p.ID, &p.Name, &p.Score, &p.Type); err != nil {
+		if err := rows.Scan(&p.ID, &p.Name, &p.Score, &p.Type, &p.Position, &p.Label, &sectionID, &section); err != nil {
 			log.Printf("problem-store: row scan failed: %v", err)
 			return nil, fmt.Errorf("scan problem row: %w", err)
 		}
 
+		p.SectionID = sectionID.String
+		p.Section = section.String
+
 		problems = append(problems, p)
 	}
 
@@ -137,15 +300,17 @@ func (s *ProblemStore) GetProblemList(ctx context.Context, contestID string) ([]
 
 func (s *ProblemStore) GetProblem(ctx context.Context, problemID string, contestID string) (*dto.GetProblemStatementResponse, error) {
 	const q = `
-		SELECT id, contest_id, name, description, score, type
-		FROM problems
-		WHERE id = $1 AND contest_id = $2
+		SELECT p.id, cp.contest_id, p.name, cp.label, cp.section_id, p.description, COALESCE(cp.score, p.score), p.type, p.multiple_choice, p.negative_marks, p.partial_credit
+		FROM contest_problems cp
+		JOIN problems p ON p.id = cp.problem_id
+		WHERE cp.problem_id = $1 AND cp.contest_id = $2
 	`
 
 	var p dto.GetProblemStatementResponse
+	var sectionID sql.NullString
 
 	err := s.db.QueryRowContext(ctx, q, problemID, contestID).Scan(
-		&p.ProblemID, &p.ContestID, &p.Name, &p.Description, &p.Score, &p.Type,
+		&p.ProblemID, &p.ContestID, &p.Name, &p.Label, &sectionID, &p.Description, &p.Score, &p.Type, &p.MultipleChoice, &p.NegativeMarks, &p.PartialCredit,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -156,5 +321,890 @@ func (s *ProblemStore) GetProblem(ctx context.Context, problemID string, contest
 		return nil, fmt.Errorf("query problem: %w", err)
 	}
 
+	p.SectionID = sectionID.String
+	return &p, nil
+}
+
+func (s *ProblemStore) CountProblems(ctx context.Context, contestID string) (int, error) {
+	if s == nil || s.db == nil {
+		return 0, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `SELECT COUNT(*) FROM contest_problems WHERE contest_id = $1`
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, q, contestID).Scan(&count); err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return 0, fmt.Errorf("count problems: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListProblemsWithoutTestCases returns the names of code problems in a contest that have no test cases
+func (s *ProblemStore) ListProblemsWithoutTestCases(ctx context.Context, contestID string) ([]string, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		SELECT p.name
+		FROM contest_problems cp
+		JOIN problems p ON p.id = cp.problem_id
+		WHERE cp.contest_id = $1
+			AND p.type = 'code'
+			AND NOT EXISTS (SELECT 1 FROM test_cases t WHERE t.problem_id = p.id)
+		ORDER BY p.name
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID)
+	if err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query problems without test cases: %w", err)
+	}
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			log.Printf("problem-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan problem row: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return names, nil
+}
+
+func (s *ProblemStore) CreateTestCase(ctx context.Context, tc *models.TestCase) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO test_cases (id, problem_id, is_sample, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := s.db.ExecContext(ctx, q, tc.ID, tc.ProblemID, tc.IsSample, tc.CreatedAt)
+	if err != nil {
+		log.Printf("problem-store: insert test case failed: %v", err)
+		return fmt.Errorf("insert test case: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProblemStore) ListTestCases(ctx context.Context, problemID string) ([]models.TestCase, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, problem_id, is_sample, created_at
+		FROM test_cases
+		WHERE problem_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, problemID)
+	if err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query test cases: %w", err)
+	}
+	defer rows.Close()
+
+	testCases := make([]models.TestCase, 0)
+	for rows.Next() {
+		var tc models.TestCase
+		if err := rows.Scan(&tc.ID, &tc.ProblemID, &tc.IsSample, &tc.CreatedAt); err != nil {
+			log.Printf("problem-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan test case row: %w", err)
+		}
+		testCases = append(testCases, tc)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return testCases, nil
+}
+
+func (s *ProblemStore) DeleteTestCase(ctx context.Context, problemID string, testCaseID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `DELETE FROM test_cases WHERE id = $1 AND problem_id = $2`
+
+	res, err := s.db.ExecContext(ctx, q, testCaseID, problemID)
+	if err != nil {
+		log.Printf("problem-store: delete test case failed: %v", err)
+		return fmt.Errorf("delete test case: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("problem-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListProblems returns the full problems of a contest, including MCQ options and answers, for admin use
+func (s *ProblemStore) ListProblems(ctx context.Context, contestID string) ([]models.Problem, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + problemColumns + `
+		FROM ` + contestProblemsFrom + `
+		WHERE cp.contest_id = $1
+		ORDER BY cp.position, p.name
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID)
+	if err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query problems: %w", err)
+	}
+	defer rows.Close()
+
+	problems := make([]models.Problem, 0)
+	for rows.Next() {
+		var p models.Problem
+		if err := scanProblem(rows, &p); err != nil {
+			log.Printf("problem-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan problem row: %w", err)
+		}
+		problems = append(problems, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	for i := range problems {
+		problems[i].Assets, err = s.ListAssets(ctx, problems[i].ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if problems[i].Type != models.MCQ {
+			continue
+		}
+
+		problems[i].Options, err = s.ListOptions(ctx, problems[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return problems, nil
+}
+
+// GetProblemDetails returns the full problem, including MCQ options and answer, for grading and admin use
+func (s *ProblemStore) GetProblemDetails(ctx context.Context, problemID string, contestID string) (*models.Problem, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + problemColumns + `
+		FROM ` + contestProblemsFrom + `
+		WHERE cp.problem_id = $1 AND cp.contest_id = $2
+	`
+
+	var p models.Problem
+	if err := scanProblem(s.db.QueryRowContext(ctx, q, problemID, contestID), &p); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ContestNotFoundError
+		}
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query problem: %w", err)
+	}
+
+	if p.Type == models.MCQ {
+		options, err := s.ListOptions(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		p.Options = options
+	}
+
 	return &p, nil
 }
+
+// problemColumns lists the columns read by scanProblem, in order, for a problem of a contest
+const problemColumns = `p.id, cp.contest_id, p.name, p.description, COALESCE(cp.score, p.score), p.type, p.answer, p.multiple_choice, p.negative_marks, p.partial_credit, cp.pool, cp.position, cp.label, cp.section_id, p.tags, p.difficulty, p.next_option_id`
+
+const contestProblemsFrom = `contest_problems cp JOIN problems p ON p.id = cp.problem_id`
+
+// bankProblemColumns lists the columns read by scanProblem for a problem outside of any contest
+const bankProblemColumns = `p.id, '', p.name, p.description, p.score, p.type, p.answer, p.multiple_choice, p.negative_marks, p.partial_credit, NULL, 0, '', NULL, p.tags, p.difficulty, p.next_option_id`
+
+func scanProblem(row rowScanner, p *models.Problem) error {
+	var description, pool, sectionID, difficulty sql.NullString
+	var answer pq.Int64Array
+
+	err := row.Scan(
+		&p.ID,
+		&p.ContestID,
+		&p.Name,
+		&description,
+		&p.Score,
+		&p.Type,
+		&answer,
+		&p.MultipleChoice,
+		&p.NegativeMarks,
+		&p.PartialCredit,
+		&pool,
+		&p.Position,
+		&p.Label,
+		&sectionID,
+		pq.Array(&p.Tags),
+		&difficulty,
+		&p.NextOptionID,
+	)
+	if err != nil {
+		return err
+	}
+
+	p.Description = description.String
+	p.Pool = pool.String
+	p.SectionID = sectionID.String
+	p.Difficulty = models.Difficulty(difficulty.String)
+	for _, a := range answer {
+		p.Answer = append(p.Answer, int(a))
+	}
+	return nil
+}
+
+// ListExistingProblemIDs returns which of the given problem IDs already exist
+func (s *ProblemStore) ListExistingProblemIDs(ctx context.Context, problemIDs []string) ([]string, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `SELECT id FROM problems WHERE id = ANY($1)`
+
+	rows, err := s.db.QueryContext(ctx, q, pq.Array(problemIDs))
+	if err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query problem ids: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			log.Printf("problem-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan problem id: %w", err)
+		}
+		existing = append(existing, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return existing, nil
+}
+
+func (s *ProblemStore) ListOptions(ctx context.Context, problemID string) ([]models.ProblemOption, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, position, text, image, weight
+		FROM problem_options
+		WHERE problem_id = $1
+		ORDER BY position, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, problemID)
+	if err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query options: %w", err)
+	}
+	defer rows.Close()
+
+	options := make([]models.ProblemOption, 0)
+	for rows.Next() {
+		var o models.ProblemOption
+		var image sql.NullString
+
+		if err := rows.Scan(&o.ID, &o.Position, &o.Text, &image, &o.Weight); err != nil {
+			log.Printf("problem-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan option row: %w", err)
+		}
+
+		o.Image = image.String
+		options = append(options, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return options, nil
+}
+
+// CreateOption adds an option to a problem, assigning it the problem's next option ID and,
+// if no position is given, placing it last
+func (s *ProblemStore) CreateOption(ctx context.Context, problemID string, o *models.ProblemOption) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("problem-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Taking the ID locks the problem row, so concurrent inserts get distinct IDs and positions
+	const idQ = `
+		UPDATE problems
+		SET next_option_id = next_option_id + 1
+		WHERE id = $1
+		RETURNING next_option_id - 1
+	`
+
+	if err := tx.QueryRowContext(ctx, idQ, problemID).Scan(&o.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return common.ProblemNotFoundError
+		}
+		log.Printf("problem-store: update option id failed: %v", err)
+		return fmt.Errorf("update option id: %w", err)
+	}
+
+	const q = `
+		INSERT INTO problem_options (problem_id, id, position, text, image, weight)
+		SELECT $1, $2, COALESCE(NULLIF($3, 0), COALESCE(MAX(position), 0) + 1), $4, $5, $6
+		FROM problem_options
+		WHERE problem_id = $1
+		RETURNING position
+	`
+
+	err = tx.QueryRowContext(ctx, q, problemID, o.ID, o.Position, o.Text, o.Image, o.Weight).Scan(&o.Position)
+	if err != nil {
+		log.Printf("problem-store: insert option failed: %v", err)
+		return fmt.Errorf("insert option: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("problem-store: commit failed: %v", err)
+		return fmt.Errorf("commit option: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProblemStore) UpdateOption(ctx context.Context, problemID string, o *models.ProblemOption) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		UPDATE problem_options
+		SET position = $3, text = $4, image = $5, weight = $6
+		WHERE problem_id = $1 AND id = $2
+	`
+
+	res, err := s.db.ExecContext(ctx, q, problemID, o.ID, o.Position, o.Text, o.Image, o.Weight)
+	if err != nil {
+		log.Printf("problem-store: update option failed: %v", err)
+		return fmt.Errorf("update option: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("problem-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.OptionNotFoundError
+	}
+
+	return nil
+}
+
+// DeleteOption removes an option and drops it from the problem's answer
+func (s *ProblemStore) DeleteOption(ctx context.Context, problemID string, optionID int) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("problem-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const q = `DELETE FROM problem_options WHERE problem_id = $1 AND id = $2`
+
+	res, err := tx.ExecContext(ctx, q, problemID, optionID)
+	if err != nil {
+		log.Printf("problem-store: delete option failed: %v", err)
+		return fmt.Errorf("delete option: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("problem-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.OptionNotFoundError
+	}
+
+	const answerQ = `UPDATE problems SET answer = array_remove(answer, $2) WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, answerQ, problemID, optionID); err != nil {
+		log.Printf("problem-store: update answer failed: %v", err)
+		return fmt.Errorf("update answer: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("problem-store: commit failed: %v", err)
+		return fmt.Errorf("commit option: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ProblemStore) CreateAsset(ctx context.Context, a *models.ProblemAsset) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO problem_assets (id, problem_id, name, content_type, size, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (problem_id, name) DO NOTHING
+	`
+
+	res, err := s.db.ExecContext(ctx, q, a.ID, a.ProblemID, a.Name, a.ContentType, a.Size, a.CreatedAt)
+	if err != nil {
+		log.Printf("problem-store: insert asset failed: %v", err)
+		return fmt.Errorf("insert asset: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("problem-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.AssetAlreadyExistsError
+	}
+
+	return nil
+}
+
+func insertAsset(ctx context.Context, db execer, a *models.ProblemAsset) error {
+	const q = `
+		INSERT INTO problem_assets (id, problem_id, name, content_type, size, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := db.ExecContext(ctx, q, a.ID, a.ProblemID, a.Name, a.ContentType, a.Size, a.CreatedAt)
+	return err
+}
+
+func (s *ProblemStore) ListAssets(ctx context.Context, problemID string) ([]models.ProblemAsset, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, problem_id, name, content_type, size, created_at
+		FROM problem_assets
+		WHERE problem_id = $1
+		ORDER BY name
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, problemID)
+	if err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]models.ProblemAsset, 0)
+	for rows.Next() {
+		var a models.ProblemAsset
+		if err := rows.Scan(&a.ID, &a.ProblemID, &a.Name, &a.ContentType, &a.Size, &a.CreatedAt); err != nil {
+			log.Printf("problem-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan asset row: %w", err)
+		}
+		assets = append(assets, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return assets, nil
+}
+
+func (s *ProblemStore) GetAssetByName(ctx context.Context, problemID string, name string) (*models.ProblemAsset, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, problem_id, name, content_type, size, created_at
+		FROM problem_assets
+		WHERE problem_id = $1 AND name = $2
+	`
+
+	var a models.ProblemAsset
+	err := s.db.QueryRowContext(ctx, q, problemID, name).Scan(&a.ID, &a.ProblemID, &a.Name, &a.ContentType, &a.Size, &a.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.AssetNotFoundError
+		}
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query asset: %w", err)
+	}
+
+	return &a, nil
+}
+
+func (s *ProblemStore) DeleteAsset(ctx context.Context, problemID string, assetID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `DELETE FROM problem_assets WHERE id = $1 AND problem_id = $2`
+
+	res, err := s.db.ExecContext(ctx, q, assetID, problemID)
+	if err != nil {
+		log.Printf("problem-store: delete asset failed: %v", err)
+		return fmt.Errorf("delete asset: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("problem-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.AssetNotFoundError
+	}
+
+	return nil
+}
+
+// ReorderProblems sets the positions of the problems of a contest to their order in problemIDs,
+// and their labels if given. problemIDs must list every problem of the contest.
+func (s *ProblemStore) ReorderProblems(ctx context.Context, contestID string, problemIDs []string, labels []string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("problem-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const q = `
+		UPDATE contest_problems
+		SET position = $3, label = COALESCE(NULLIF($4, ''), label)
+		WHERE problem_id = $1 AND contest_id = $2
+	`
+
+	for i, id := range problemIDs {
+		var label string
+		if labels != nil {
+			label = labels[i]
+		}
+
+		res, err := tx.ExecContext(ctx, q, id, contestID, i+1, label)
+		if err != nil {
+			log.Printf("problem-store: reorder failed: %v", err)
+			return fmt.Errorf("reorder problems: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			log.Printf("problem-store: rows error %v", err)
+			return fmt.Errorf("rows error: %w", err)
+		}
+
+		if affected == 0 {
+			return common.InvalidProblemOrderError
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("problem-store: commit failed: %v", err)
+		return fmt.Errorf("commit reorder: %w", err)
+	}
+
+	return nil
+}
+
+// ListBankProblems lists the problems of the bank with their stats, optionally filtered by tag and difficulty
+func (s *ProblemStore) ListBankProblems(ctx context.Context, tag string, difficulty models.Difficulty, page int) ([]dto.BankProblem, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const pageSize = 20
+	page = max(0, page)
+	offset := page * pageSize
+
+	const q = `
+		SELECT ` + bankProblemColumns + `, ` + problemStatsColumns + `
+		FROM problems p
+		WHERE ($1 = '' OR $1 = ANY(p.tags))
+			AND ($2 = '' OR p.difficulty::TEXT = $2)
+		ORDER BY p.name
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, tag, string(difficulty), pageSize, offset)
+	if err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query bank problems: %w", err)
+	}
+	defer rows.Close()
+
+	problems := make([]dto.BankProblem, 0)
+	for rows.Next() {
+		var bp dto.BankProblem
+		if err := scanBankProblem(rows, &bp); err != nil {
+			log.Printf("problem-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan problem row: %w", err)
+		}
+		problems = append(problems, bp)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return problems, nil
+}
+
+// GetBankProblem returns a problem of the bank with its options, assets and stats
+func (s *ProblemStore) GetBankProblem(ctx context.Context, problemID string) (*dto.BankProblem, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + bankProblemColumns + `, ` + problemStatsColumns + `
+		FROM problems p
+		WHERE p.id = $1
+	`
+
+	var bp dto.BankProblem
+	if err := scanBankProblem(s.db.QueryRowContext(ctx, q, problemID), &bp); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ProblemNotFoundError
+		}
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query problem: %w", err)
+	}
+
+	var err error
+	bp.Assets, err = s.ListAssets(ctx, problemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bp.Type == models.MCQ {
+		bp.Options, err = s.ListOptions(ctx, problemID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &bp, nil
+}
+
+// problemStatsColumns computes the columns of models.ProblemStats for the problem p across all contests
+const problemStatsColumns = `
+	(SELECT COUNT(*) FROM contest_problems cp WHERE cp.problem_id = p.id),
+	(SELECT COUNT(DISTINCT sb.user_id) FROM submissions sb WHERE sb.problem_id = p.id AND NOT sb.practice),
+	(SELECT COUNT(DISTINCT sb.user_id) FROM submissions sb WHERE sb.problem_id = p.id AND NOT sb.practice AND sb.status = 'accepted')`
+
+func scanBankProblem(row rowScanner, bp *dto.BankProblem) error {
+	statsRow := &statsScanner{row: row, bp: bp}
+	if err := scanProblem(statsRow, &bp.Problem); err != nil {
+		return err
+	}
+
+	if bp.Stats.Attempts > 0 {
+		bp.Stats.SolveRate = float64(bp.Stats.Solves) / float64(bp.Stats.Attempts)
+	}
+	return nil
+}
+
+// statsScanner appends the problem stats columns to the destinations of scanProblem
+type statsScanner struct {
+	row rowScanner
+	bp  *dto.BankProblem
+}
+
+func (s *statsScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, &s.bp.Stats.Contests, &s.bp.Stats.Attempts, &s.bp.Stats.Solves)...)
+}
+
+// CreateBankProblem adds a problem to the bank without attaching it to a contest
+func (s *ProblemStore) CreateBankProblem(ctx context.Context, p *models.Problem) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("problem-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	p.ContestID = ""
+	if err := insertProblem(ctx, tx, p); err != nil {
+		log.Printf("problem-store: insert failed: %v", err)
+		return fmt.Errorf("insert problem: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("problem-store: commit failed: %v", err)
+		return fmt.Errorf("commit problem: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBankProblem updates a problem of the bank, including its default score, tags and difficulty
+func (s *ProblemStore) UpdateBankProblem(ctx context.Context, p *models.Problem) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	if err := updateProblem(ctx, s.db, p, true); err != nil {
+		log.Printf("problem-store: update failed: %v", err)
+		return fmt.Errorf("update problem: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBankProblem deletes a problem that is not attached to any contest
+func (s *ProblemStore) DeleteBankProblem(ctx context.Context, problemID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `
+		DELETE FROM problems p
+		WHERE p.id = $1 AND NOT EXISTS (SELECT 1 FROM contest_problems cp WHERE cp.problem_id = p.id)
+	`
+
+	res, err := s.db.ExecContext(ctx, q, problemID)
+	if err != nil {
+		log.Printf("problem-store: delete failed: %v", err)
+		return fmt.Errorf("delete problem: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("problem-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		if _, err := s.GetBankProblem(ctx, problemID); err != nil {
+			return err
+		}
+		return common.ProblemInUseError
+	}
+
+	return nil
+}
+
+// ListProblemContests lists the IDs of the contests a problem is attached to
+func (s *ProblemStore) ListProblemContests(ctx context.Context, problemID string) ([]string, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("problem store: db is not initialized")
+	}
+
+	const q = `SELECT contest_id FROM contest_problems WHERE problem_id = $1`
+
+	rows, err := s.db.QueryContext(ctx, q, problemID)
+	if err != nil {
+		log.Printf("problem-store: query failed: %v", err)
+		return nil, fmt.Errorf("query problem contests: %w", err)
+	}
+	defer rows.Close()
+
+	contestIDs := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			log.Printf("problem-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan contest id: %w", err)
+		}
+		contestIDs = append(contestIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("problem-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return contestIDs, nil
+}
+
+// AttachProblem adds a bank problem to p.ContestID with the contest specific fields of p.
+// A nil score uses the problem's own score.
+func (s *ProblemStore) AttachProblem(ctx context.Context, p *models.Problem, score *int) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("problem store: db is not initialized")
+	}
+
+	if err := attachProblem(ctx, s.db, p, score); err != nil {
+		if err == common.ProblemAlreadyAttachedError {
+			return err
+		}
+		log.Printf("problem-store: attach failed: %v", err)
+		return fmt.Errorf("attach problem: %w", err)
+	}
+
+	return nil
+}
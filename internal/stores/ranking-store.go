@@ -1,12 +1,11 @@
 package stores
 
 import (
-	"app/internal/models/dto"
+	"app/internal/models"
 	"context"
 	"database/sql"
 	"fmt"
 	"log"
-	"strings"
 )
 
 type RankingStore struct {
@@ -19,40 +18,341 @@ func NewRankingStore(db *sql.DB) *RankingStore {
 	}
 }
 
-func (s *RankingStore) UpdateLeaderboardUser(ctx context.Context, contestID string, userID string, req *dto.UpdateLeaderboardUserRequest) error {
+// UpdateLeaderboardUser hides or shows a user on the leaderboard and records a change to their
+// disqualification, when given, in a single transaction. Only users with a ranking are updated.
+func (s *RankingStore) UpdateLeaderboardUser(ctx context.Context, contestID string, userID string, hidden *bool, d *models.Disqualification) error {
 	if s == nil || s.db == nil {
 		return fmt.Errorf("ranking store: db is not initialized")
 	}
 
-	query := "UPDATE rankings SET "
-	args := []interface{}{}
-	argID := 1
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("ranking-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if d != nil {
+		if err := recordDisqualificationTx(ctx, tx, d); err != nil {
+			return err
+		}
+	}
+
+	if hidden != nil {
+		const q = `UPDATE rankings SET hidden = $3 WHERE contest_id = $1 AND user_id = $2`
+
+		if _, err := tx.ExecContext(ctx, q, contestID, userID, *hidden); err != nil {
+			log.Printf("ranking-store: update failed: %v", err)
+			return fmt.Errorf("update ranking: %w", err)
+		}
+
+		if err := updateTeamRankingFlags(ctx, tx, contestID, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("ranking-store: commit failed: %v", err)
+		return fmt.Errorf("commit ranking: %w", err)
+	}
+
+	return nil
+}
+
+// GetRanking returns the user's ranking in a contest, or nil if they have none
+func (s *RankingStore) GetRanking(ctx context.Context, contestID string, userID string) (*models.Ranking, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("ranking store: db is not initialized")
+	}
+
+	const q = `
+		SELECT contest_id, user_id, score, hidden, disqualified, shortlisted
+		FROM rankings
+		WHERE contest_id = $1 AND user_id = $2
+	`
+
+	var r models.Ranking
+	err := s.db.QueryRowContext(ctx, q, contestID, userID).Scan(&r.ContestID, &r.UserID, &r.Score, &r.Hidden, &r.Disqualified, &r.Shortlisted)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("ranking-store: query failed: %v", err)
+		return nil, fmt.Errorf("query ranking: %w", err)
+	}
+
+	return &r, nil
+}
+
+// lockSubmissionRankings creates the rankings the graded submission counts towards if they are
+// missing and locks them, so the submissions of a user or team are scored one at a time. A user
+// ranking created here carries over a disqualification recorded before the user had one.
+func lockSubmissionRankings(ctx context.Context, tx *sql.Tx, sub *models.Submission) error {
+	const rankingQ = `
+		INSERT INTO rankings (contest_id, user_id, disqualified)
+		VALUES ($1, $2, COALESCE((
+			SELECT action = 'disqualify'
+			FROM disqualifications
+			WHERE contest_id = $1 AND user_id = $2
+			ORDER BY seq DESC
+			LIMIT 1
+		), FALSE))
+		ON CONFLICT (contest_id, user_id) DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, rankingQ, sub.ContestID, sub.UserID); err != nil {
+		log.Printf("ranking-store: insert ranking failed: %v", err)
+		return fmt.Errorf("insert ranking: %w", err)
+	}
+
+	const lockQ = `SELECT 1 FROM rankings WHERE contest_id = $1 AND user_id = $2 FOR UPDATE`
+
+	var locked int
+	if err := tx.QueryRowContext(ctx, lockQ, sub.ContestID, sub.UserID).Scan(&locked); err != nil {
+		log.Printf("ranking-store: lock ranking failed: %v", err)
+		return fmt.Errorf("lock ranking: %w", err)
+	}
+
+	if sub.TeamID == "" {
+		return nil
+	}
+
+	const teamRankingQ = `
+		INSERT INTO team_rankings (contest_id, team_id)
+		VALUES ($1, $2)
+		ON CONFLICT (contest_id, team_id) DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, teamRankingQ, sub.ContestID, sub.TeamID); err != nil {
+		log.Printf("ranking-store: insert team ranking failed: %v", err)
+		return fmt.Errorf("insert team ranking: %w", err)
+	}
+
+	const teamLockQ = `SELECT 1 FROM team_rankings WHERE contest_id = $1 AND team_id = $2 FOR UPDATE`
+
+	if err := tx.QueryRowContext(ctx, teamLockQ, sub.ContestID, sub.TeamID).Scan(&locked); err != nil {
+		log.Printf("ranking-store: lock team ranking failed: %v", err)
+		return fmt.Errorf("lock team ranking: %w", err)
+	}
+
+	return nil
+}
 
-	if req.Hidden != nil {
-		query += fmt.Sprintf("hidden = $%d, ", argID)
-		args = append(args, *req.Hidden)
-		argID++
+// applySubmissionScore adds the change a graded submission makes to its problem onto the contest
+// score of the user, and of their team if they are in one. The change is its score less the score
+// of the previous graded submission to the problem, by the user or by any member of the team.
+// Scoring by difference leaves the points of code submissions, which are graded elsewhere, in place.
+// The score is kept from going below zero when the contest is configured to. The rankings must have
+// been locked with lockSubmissionRankings before the submission was inserted as seq.
+func applySubmissionScore(ctx context.Context, tx *sql.Tx, sub *models.Submission, seq int64) error {
+	const q = `
+		WITH previous AS (
+			SELECT score
+			FROM submissions
+			WHERE contest_id = $1 AND user_id = $2 AND problem_id = $3 AND score IS NOT NULL AND NOT practice
+				AND seq < $4
+			ORDER BY seq DESC
+			LIMIT 1
+		), delta AS (
+			SELECT $5::INT - COALESCE((SELECT score FROM previous), 0) AS delta
+		)
+		UPDATE rankings r
+		SET score = CASE WHEN c.clamp_score THEN GREATEST(r.score + d.delta, 0) ELSE r.score + d.delta END
+		FROM contests c, delta d
+		WHERE c.id = r.contest_id AND r.contest_id = $1 AND r.user_id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, q, sub.ContestID, sub.UserID, sub.ProblemID, seq, sub.Score); err != nil {
+		log.Printf("ranking-store: apply submission score failed: %v", err)
+		return fmt.Errorf("apply submission score: %w", err)
+	}
+
+	if sub.TeamID == "" {
+		return nil
 	}
-	if req.Disqualified != nil {
-		query += fmt.Sprintf("disqualified = $%d, ", argID)
-		args = append(args, *req.Disqualified)
-		argID++
+
+	const teamQ = `
+		WITH previous AS (
+			SELECT score
+			FROM submissions
+			WHERE contest_id = $1 AND team_id = $2 AND problem_id = $3 AND score IS NOT NULL AND NOT practice
+				AND seq < $4
+			ORDER BY seq DESC
+			LIMIT 1
+		), delta AS (
+			SELECT $5::INT - COALESCE((SELECT score FROM previous), 0) AS delta
+		)
+		UPDATE team_rankings tr
+		SET score = CASE WHEN c.clamp_score THEN GREATEST(tr.score + d.delta, 0) ELSE tr.score + d.delta END
+		FROM contests c, delta d
+		WHERE c.id = tr.contest_id AND tr.contest_id = $1 AND tr.team_id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, teamQ, sub.ContestID, sub.TeamID, sub.ProblemID, seq, sub.Score); err != nil {
+		log.Printf("ranking-store: apply team submission score failed: %v", err)
+		return fmt.Errorf("apply team submission score: %w", err)
 	}
 
-	if len(args) == 0 {
-		return fmt.Errorf("no fields to update")
+	// A team ranking created for this submission takes the flags of the team's members
+	return updateTeamRankingFlags(ctx, tx, sub.ContestID, sub.UserID)
+}
+
+// updateTeamRankingFlags recomputes the ranking of the user's team in the contest, which is hidden
+// while any member is hidden and disqualified while any member is disqualified. Users outside of
+// any team, and teams without a ranking, are skipped.
+func updateTeamRankingFlags(ctx context.Context, db execer, contestID string, userID string) error {
+	const q = `
+		UPDATE team_rankings tr
+		SET hidden = EXISTS (
+				SELECT 1
+				FROM team_members tm
+				JOIN rankings r ON r.contest_id = tm.contest_id AND r.user_id = tm.user_id
+				WHERE tm.team_id = tr.team_id AND r.hidden
+			),
+			disqualified = EXISTS (
+				SELECT 1
+				FROM team_members tm
+				WHERE tm.team_id = tr.team_id AND (
+					SELECT d.action
+					FROM disqualifications d
+					WHERE d.contest_id = tm.contest_id AND d.user_id = tm.user_id
+					ORDER BY d.seq DESC
+					LIMIT 1
+				) = 'disqualify'
+			)
+		WHERE tr.contest_id = $1 AND tr.team_id = (
+			SELECT team_id FROM team_members WHERE contest_id = $1 AND user_id = $2
+		)
+	`
+
+	if _, err := db.ExecContext(ctx, q, contestID, userID); err != nil {
+		log.Printf("ranking-store: update team flags failed: %v", err)
+		return fmt.Errorf("update team ranking: %w", err)
 	}
 
-	query = strings.TrimSuffix(query, ", ")
+	return nil
+}
+
+// GetVirtualLeaderboard ranks a virtual participant, elapsed seconds into their participation, among the
+// official participants scored by their ranked submissions made within the same time of their own start.
+// Hidden and disqualified participants are left out.
+func (s *RankingStore) GetVirtualLeaderboard(ctx context.Context, contestID string, userID string, elapsed int64) ([]models.VirtualRanking, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("ranking store: db is not initialized")
+	}
 
-	query += fmt.Sprintf(" WHERE contest_id = $%d AND user_id = $%d", argID, argID+1)
-	args = append(args, contestID, userID)
+	const q = `
+		WITH official AS (
+			SELECT DISTINCT ON (s.user_id, s.problem_id) s.user_id, s.score
+			FROM submissions s
+			JOIN contests c ON c.id = s.contest_id
+			LEFT JOIN contest_registrations reg ON reg.contest_id = s.contest_id AND reg.user_id = s.user_id
+			WHERE s.contest_id = $1 AND s.user_id <> $2 AND s.score IS NOT NULL AND NOT s.practice
+				AND s.created_at - COALESCE(reg.started_at, c.start_time) / 1000 <= $3
+			ORDER BY s.user_id, s.problem_id, s.seq DESC
+		), virtual AS (
+			SELECT DISTINCT ON (problem_id) score
+			FROM submissions
+			WHERE contest_id = $1 AND user_id = $2 AND score IS NOT NULL AND virtual
+			ORDER BY problem_id, seq DESC
+		), scores AS (
+			SELECT o.user_id, SUM(o.score) AS score, FALSE AS virtual
+			FROM official o
+			LEFT JOIN rankings r ON r.contest_id = $1 AND r.user_id = o.user_id
+			WHERE NOT COALESCE(r.hidden, FALSE) AND NOT COALESCE(r.disqualified, FALSE)
+			GROUP BY o.user_id
+			UNION ALL
+			SELECT $2, COALESCE(SUM(score), 0), TRUE
+			FROM virtual
+		), clamped AS (
+			SELECT sc.user_id, CASE WHEN c.clamp_score THEN GREATEST(sc.score, 0) ELSE sc.score END AS score, sc.virtual
+			FROM scores sc
+			JOIN contests c ON c.id = $1
+		)
+		SELECT user_id, score, RANK() OVER (ORDER BY score DESC) AS rank, virtual
+		FROM clamped
+		ORDER BY rank ASC, virtual DESC, user_id ASC
+	`
 
-	_, err := s.db.ExecContext(ctx, query, args...)
+	rows, err := s.db.QueryContext(ctx, q, contestID, userID, elapsed)
 	if err != nil {
-		log.Printf("ranking-store: update failed: %v", err)
-		return fmt.Errorf("update ranking: %w", err)
+		log.Printf("ranking-store: query failed: %v", err)
+		return nil, fmt.Errorf("query virtual leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	rankings := make([]models.VirtualRanking, 0)
+	for rows.Next() {
+		var r models.VirtualRanking
+		if err := rows.Scan(&r.UserID, &r.Score, &r.Rank, &r.Virtual); err != nil {
+			log.Printf("ranking-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan virtual ranking row: %w", err)
+		}
+		rankings = append(rankings, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("ranking-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return rankings, nil
+}
+
+// RefreshTeamLeaderboard recomputes the team leaderboard view without blocking its readers
+func (s *RankingStore) RefreshTeamLeaderboard(ctx context.Context) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("ranking store: db is not initialized")
+	}
+
+	if _, err := s.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY team_ranking_mv`); err != nil {
+		log.Printf("ranking-store: refresh failed: %v", err)
+		return fmt.Errorf("refresh team leaderboard: %w", err)
 	}
 
 	return nil
 }
+
+// GetTeamLeaderboard returns a page of the team leaderboard as of its last refresh
+func (s *RankingStore) GetTeamLeaderboard(ctx context.Context, contestID string, page int) ([]models.TeamRanking, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("ranking store: db is not initialized")
+	}
+
+	const pageSize = 20
+	page = max(0, page)
+	offset := page * pageSize
+
+	const q = `
+		SELECT mv.team_id, t.name, mv.score, mv.rank
+		FROM team_ranking_mv mv
+		JOIN teams t ON t.id = mv.team_id
+		WHERE mv.contest_id = $1
+		ORDER BY mv.rank ASC, t.name ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID, pageSize, offset)
+	if err != nil {
+		log.Printf("ranking-store: query failed: %v", err)
+		return nil, fmt.Errorf("query team leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	rankings := make([]models.TeamRanking, 0)
+	for rows.Next() {
+		var r models.TeamRanking
+		if err := rows.Scan(&r.TeamID, &r.TeamName, &r.Score, &r.Rank); err != nil {
+			log.Printf("ranking-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan team ranking row: %w", err)
+		}
+		rankings = append(rankings, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("ranking-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return rankings, nil
+}
@@ -0,0 +1,147 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+type SectionStore struct {
+	db *sql.DB
+}
+
+func NewSectionStore(db *sql.DB) *SectionStore {
+	return &SectionStore{
+		db: db,
+	}
+}
+
+func (s *SectionStore) ListSections(ctx context.Context, contestID string) ([]models.ContestSection, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("section store: db is not initialized")
+	}
+
+	const q = `
+		SELECT id, contest_id, name, instructions, position
+		FROM contest_sections
+		WHERE contest_id = $1
+		ORDER BY position, name
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, contestID)
+	if err != nil {
+		log.Printf("section-store: query failed: %v", err)
+		return nil, fmt.Errorf("query sections: %w", err)
+	}
+	defer rows.Close()
+
+	sections := make([]models.ContestSection, 0)
+	for rows.Next() {
+		var sec models.ContestSection
+		if err := rows.Scan(&sec.ID, &sec.ContestID, &sec.Name, &sec.Instructions, &sec.Position); err != nil {
+			log.Printf("section-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan section row: %w", err)
+		}
+		sections = append(sections, sec)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("section-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return sections, nil
+}
+
+// CreateSection adds a section to a contest, placing it last if no position is given
+func (s *SectionStore) CreateSection(ctx context.Context, sec *models.ContestSection) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("section store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO contest_sections (id, contest_id, name, instructions, position)
+		SELECT $1, $2, $3, $4, COALESCE(NULLIF($5, 0), COALESCE(MAX(position), 0) + 1)
+		FROM contest_sections
+		WHERE contest_id = $2
+		RETURNING position
+	`
+
+	err := s.db.QueryRowContext(ctx, q, sec.ID, sec.ContestID, sec.Name, sec.Instructions, sec.Position).Scan(&sec.Position)
+	if err != nil {
+		log.Printf("section-store: insert failed: %v", err)
+		return fmt.Errorf("insert section: %w", err)
+	}
+
+	return nil
+}
+
+func insertSection(ctx context.Context, db execer, sec *models.ContestSection) error {
+	const q = `
+		INSERT INTO contest_sections (id, contest_id, name, instructions, position)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := db.ExecContext(ctx, q, sec.ID, sec.ContestID, sec.Name, sec.Instructions, sec.Position)
+	return err
+}
+
+func (s *SectionStore) UpdateSection(ctx context.Context, sec *models.ContestSection) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("section store: db is not initialized")
+	}
+
+	const q = `
+		UPDATE contest_sections
+		SET name = $3, instructions = $4, position = $5
+		WHERE id = $1 AND contest_id = $2
+	`
+
+	res, err := s.db.ExecContext(ctx, q, sec.ID, sec.ContestID, sec.Name, sec.Instructions, sec.Position)
+	if err != nil {
+		log.Printf("section-store: update failed: %v", err)
+		return fmt.Errorf("update section: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("section-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.SectionNotFoundError
+	}
+
+	return nil
+}
+
+// DeleteSection removes a section, its problems stay in the contest without a section
+func (s *SectionStore) DeleteSection(ctx context.Context, contestID string, sectionID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("section store: db is not initialized")
+	}
+
+	const q = `DELETE FROM contest_sections WHERE id = $1 AND contest_id = $2`
+
+	res, err := s.db.ExecContext(ctx, q, sectionID, contestID)
+	if err != nil {
+		log.Printf("section-store: delete failed: %v", err)
+		return fmt.Errorf("delete section: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("section-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.SectionNotFoundError
+	}
+
+	return nil
+}
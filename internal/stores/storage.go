@@ -11,29 +11,52 @@ import (
 type Storage struct {
 	// Declarations of method extensions for each store go here
 	Contests interface {
-		ListContests(context.Context, int) ([]models.Contest, error)
+		ListContests(context.Context, int, []models.ContestStatus, bool) ([]models.Contest, error)
 		IsRegistered(context.Context, string, string) (bool, error)
 		CreateContest(ctx context.Context, c *models.Contest) error
 		UpdateContest(ctx context.Context, c *models.Contest) error
 		DeleteContest(ctx context.Context, contestID string) error
+		UpdateContestStatus(ctx context.Context, contestID string, from models.ContestStatus, to models.ContestStatus) error
+		CreateContestWithProblems(ctx context.Context, c *models.Contest, problems []models.Problem, testCases []models.TestCase, pools []models.ContestPool, sections []models.ContestSection) error
+		ListTemplates(ctx context.Context, page int) ([]models.Contest, error)
 		GetContest(context.Context, string) (*dto.GetContestResponse, error)
-		RegisterUser(context.Context, string, string) error
+		RegisterUser(ctx context.Context, contestID string, userID string, inviteCode string) (bool, error)
 		UnregisterUser(context.Context, string, string) error
+		GetWaitlistPosition(ctx context.Context, contestID string, userID string) (int, error)
+		ListWaitlist(ctx context.Context, contestID string) ([]models.WaitlistEntry, error)
+		GetAccess(ctx context.Context, contestID string, userID string) (models.ContestAccess, error)
+		ListAccess(ctx context.Context, contestID string) ([]models.AccessListEntry, error)
+		SetAccess(ctx context.Context, e *models.AccessListEntry) error
+		DeleteAccess(ctx context.Context, contestID string, userID string) error
+		StartAttempt(ctx context.Context, contestID string, userID string, startedAt int64) error
+		GetAttemptStart(ctx context.Context, contestID string, userID string) (int64, error)
+		StartVirtual(ctx context.Context, contestID string, userID string, startedAt int64) error
+		GetVirtualStart(ctx context.Context, contestID string, userID string) (int64, error)
 	}
 	Users interface {
 		CreateUser(context.Context, *auth.UserRecord, *dto.CreateUserRequest) error
 		GetUserProfile(context.Context, string) (*models.User, error)
 		UpdateUserProfile(context.Context, string, *dto.UpdateUserProfileRequest) error
+		SearchUsers(ctx context.Context, req *dto.AdminListUsersRequest) ([]dto.AdminUser, error)
+		GetAdminUser(ctx context.Context, userID string) (*dto.AdminUser, error)
+		ListUserRegistrations(ctx context.Context, userID string) ([]dto.UserRegistration, error)
+		AdminUpdateUser(ctx context.Context, userID string, req *dto.AdminUpdateUserRequest) error
+		SetBan(ctx context.Context, userID string, bannedAt int64, reason string, adminID string) error
 	}
 	Submissions interface {
 		GetSubmissionStatusByID(context.Context, string) (*models.Submission, error)
 		GetSubmissionDetailsByID(context.Context, string) (*dto.GetSubmissionDetailsResponse, error)
 		GetTestCaseResultsBySubmissionID(context.Context, string) ([]models.TestCaseResult, error)
 		ListUserSubmissionsByProblemID(context.Context, string, string, int) ([]models.Submission, error)
+		ListUserSubmissions(ctx context.Context, userID string, page int) ([]models.Submission, error)
 		CreateSubmission(context.Context, *models.Submission) (string, error)
 	}
 	Rankings interface {
-		UpdateLeaderboardUser(ctx context.Context, contestID string, userID string, req *dto.UpdateLeaderboardUserRequest) error
+		GetRanking(ctx context.Context, contestID string, userID string) (*models.Ranking, error)
+		UpdateLeaderboardUser(ctx context.Context, contestID string, userID string, hidden *bool, d *models.Disqualification) error
+		GetVirtualLeaderboard(ctx context.Context, contestID string, userID string, elapsed int64) ([]models.VirtualRanking, error)
+		RefreshTeamLeaderboard(ctx context.Context) error
+		GetTeamLeaderboard(ctx context.Context, contestID string, page int) ([]models.TeamRanking, error)
 	}
 	Problems interface {
 		CreateProblem(ctx context.Context, p *models.Problem) error
@@ -41,19 +64,162 @@ type Storage struct {
 		DeleteProblem(ctx context.Context, contestID string, problemID string) error
 		GetProblemList(ctx context.Context, contestID string) ([]dto.ProblemOverview, error)
 		GetProblem(ctx context.Context, problemID string, contestID string) (*dto.GetProblemStatementResponse, error)
+		GetProblemDetails(ctx context.Context, problemID string, contestID string) (*models.Problem, error)
+		ListProblems(ctx context.Context, contestID string) ([]models.Problem, error)
+		CountProblems(ctx context.Context, contestID string) (int, error)
+		ListExistingProblemIDs(ctx context.Context, problemIDs []string) ([]string, error)
+		ListProblemContests(ctx context.Context, problemID string) ([]string, error)
+		ListProblemsWithoutTestCases(ctx context.Context, contestID string) ([]string, error)
+		ListOptions(ctx context.Context, problemID string) ([]models.ProblemOption, error)
+		CreateOption(ctx context.Context, problemID string, o *models.ProblemOption) error
+		UpdateOption(ctx context.Context, problemID string, o *models.ProblemOption) error
+		DeleteOption(ctx context.Context, problemID string, optionID int) error
+		ReorderProblems(ctx context.Context, contestID string, problemIDs []string, labels []string) error
+		ListBankProblems(ctx context.Context, tag string, difficulty models.Difficulty, page int) ([]dto.BankProblem, error)
+		GetBankProblem(ctx context.Context, problemID string) (*dto.BankProblem, error)
+		CreateBankProblem(ctx context.Context, p *models.Problem) error
+		UpdateBankProblem(ctx context.Context, p *models.Problem) error
+		DeleteBankProblem(ctx context.Context, problemID string) error
+		AttachProblem(ctx context.Context, p *models.Problem, score *int) error
+		CreateAsset(ctx context.Context, a *models.ProblemAsset) error
+		ListAssets(ctx context.Context, problemID string) ([]models.ProblemAsset, error)
+		GetAssetByName(ctx context.Context, problemID string, name string) (*models.ProblemAsset, error)
+		DeleteAsset(ctx context.Context, problemID string, assetID string) error
+		CreateTestCase(ctx context.Context, tc *models.TestCase) error
+		ListTestCases(ctx context.Context, problemID string) ([]models.TestCase, error)
+		DeleteTestCase(ctx context.Context, problemID string, testCaseID string) error
+	}
+	Editorials interface {
+		GetEditorial(ctx context.Context, contestID string, problemID string) (*models.ProblemEditorial, error)
+		UpsertEditorial(ctx context.Context, e *models.ProblemEditorial) error
+		DeleteEditorial(ctx context.Context, contestID string, problemID string) error
+		ListSolutions(ctx context.Context, contestID string, problemID string) ([]models.ReferenceSolution, error)
+		CreateSolution(ctx context.Context, sol *models.ReferenceSolution) error
+		DeleteSolution(ctx context.Context, contestID string, problemID string, solutionID string) error
+	}
+	Invites interface {
+		ListInviteCodes(ctx context.Context, contestID string) ([]models.InviteCode, error)
+		CreateInviteCode(ctx context.Context, i *models.InviteCode) error
+		DeleteInviteCode(ctx context.Context, contestID string, code string) error
+		CheckInviteCode(ctx context.Context, contestID string, code string, userID string, now int64) error
+		InviteShortlisted(ctx context.Context, contestID string, sourceContestID string, adminID string, addedAt int64) (int, error)
+	}
+	Drives interface {
+		ListDrives(ctx context.Context, page int) ([]models.RecruitmentDrive, error)
+		GetDrive(ctx context.Context, driveID string) (*models.RecruitmentDrive, error)
+		CreateDrive(ctx context.Context, d *models.RecruitmentDrive) error
+		UpdateDrive(ctx context.Context, d *models.RecruitmentDrive) error
+		DeleteDrive(ctx context.Context, driveID string) error
+		SetRounds(ctx context.Context, driveID string, contestIDs []string) error
+		GetProgress(ctx context.Context, driveID string, userID string) ([]models.DriveRoundProgress, error)
+		GetFunnel(ctx context.Context, driveID string) ([]models.DriveFunnelRound, error)
+		IsPromoted(ctx context.Context, contestID string, userID string) (bool, error)
+	}
+	Interviews interface {
+		ListInterviews(ctx context.Context, driveID string, userID string) ([]models.Interview, error)
+		GetInterview(ctx context.Context, driveID string, interviewID string) (*models.Interview, error)
+		CreateInterview(ctx context.Context, i *models.Interview) error
+		UpdateInterview(ctx context.Context, i *models.Interview) error
+		DeleteInterview(ctx context.Context, driveID string, interviewID string) error
+		ListFeedback(ctx context.Context, interviewID string) ([]models.InterviewFeedback, error)
+		UpsertFeedback(ctx context.Context, f *models.InterviewFeedback) error
+		ListDecisions(ctx context.Context, driveID string) ([]models.CandidateDecision, error)
+		SetDecision(ctx context.Context, d *models.CandidateDecision) error
+	}
+	Teams interface {
+		CreateTeam(ctx context.Context, t *models.Team) error
+		GetUserTeam(ctx context.Context, contestID string, userID string) (*models.Team, error)
+		JoinTeam(ctx context.Context, contestID string, inviteCode string, userID string, maxSize int, joinedAt int64) error
+		LeaveTeam(ctx context.Context, contestID string, userID string) error
+		RegisterTeam(ctx context.Context, contestID string, teamID string, registeredAt int64) error
+		UnregisterTeam(ctx context.Context, contestID string, teamID string) error
+		GetRegisteredTeamID(ctx context.Context, contestID string, userID string) (string, error)
+	}
+	Sections interface {
+		ListSections(ctx context.Context, contestID string) ([]models.ContestSection, error)
+		CreateSection(ctx context.Context, sec *models.ContestSection) error
+		UpdateSection(ctx context.Context, sec *models.ContestSection) error
+		DeleteSection(ctx context.Context, contestID string, sectionID string) error
+	}
+	ProblemSets interface {
+		ListPools(ctx context.Context, contestID string) ([]models.ContestPool, error)
+		UpsertPool(ctx context.Context, pool *models.ContestPool) error
+		DeletePool(ctx context.Context, contestID string, tag string) error
+		GetProblemSet(ctx context.Context, contestID string, userID string) ([]models.ProblemSetEntry, error)
+		CreateProblemSet(ctx context.Context, contestID string, userID string, entries []models.ProblemSetEntry) error
+		AddProblemSetEntries(ctx context.Context, contestID string, userID string, entries []models.ProblemSetEntry) error
+	}
+	Announcements interface {
+		ListAnnouncements(ctx context.Context, contestID string) ([]models.Announcement, error)
+		GetAnnouncement(ctx context.Context, contestID string, announcementID string) (*models.Announcement, error)
+		CreateAnnouncement(ctx context.Context, a *models.Announcement) error
+		UpdateAnnouncement(ctx context.Context, a *models.Announcement) error
+		DeleteAnnouncement(ctx context.Context, contestID string, announcementID string) error
+		PublishAnnouncementEvent(ctx context.Context, event models.AnnouncementEvent) error
+	}
+	Clarifications interface {
+		ListClarifications(ctx context.Context, contestID string, status models.ClarificationStatus, page int) ([]models.Clarification, error)
+		ListUserClarifications(ctx context.Context, contestID string, userID string) ([]models.Clarification, error)
+		GetClarification(ctx context.Context, contestID string, clarificationID string) (*models.Clarification, error)
+		CreateClarification(ctx context.Context, c *models.Clarification, since int64, limit int) error
+		AnswerClarification(ctx context.Context, c *models.Clarification) error
 	}
 	Admins interface {
-		IsAdmin(ctx context.Context, userID string) (bool, error)
+		ListGrants(ctx context.Context, userID string) (models.Grants, error)
+		GrantRole(ctx context.Context, g *models.RoleGrant) error
+		RevokeRole(ctx context.Context, grantID string) error
+	}
+	Disqualifications interface {
+		ListDisqualifications(ctx context.Context, contestID string, userID string) ([]models.Disqualification, error)
+		GetLatestDisqualification(ctx context.Context, contestID string, userID string) (*models.Disqualification, error)
+		CreateAppeal(ctx context.Context, a *models.DisqualificationAppeal) error
+		GetLatestAppeal(ctx context.Context, disqualificationID string) (*models.DisqualificationAppeal, error)
+		GetAppeal(ctx context.Context, contestID string, appealID string) (*models.DisqualificationAppeal, error)
+		ListAppeals(ctx context.Context, contestID string, status models.AppealStatus, page int) ([]models.DisqualificationAppeal, error)
+		ResolveAppeal(ctx context.Context, a *models.DisqualificationAppeal, reinstatement *models.Disqualification) error
+	}
+	Suspensions interface {
+		ListSuspensions(ctx context.Context, req *dto.ListSuspensionsRequest) ([]models.Suspension, error)
+		GetActiveSuspension(ctx context.Context, userID string, contestID string, now int64) (*models.Suspension, error)
+		CreateSuspension(ctx context.Context, s *models.Suspension) error
+		LiftSuspension(ctx context.Context, s *models.Suspension) error
+	}
+	Audit interface {
+		CreateEntry(ctx context.Context, e *models.AuditEntry) error
+		ListEntries(ctx context.Context, req *dto.ListAuditLogRequest) ([]models.AuditEntry, error)
+		PurgeEntries(ctx context.Context, cutoff int64) (int64, error)
 	}
 }
 
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
 func NewStorage(db *sql.DB) *Storage {
 	return &Storage{
-		Contests:    NewContestStore(db),
-		Users:       NewUserStore(db),
-		Submissions: NewSubmissionStore(db),
-		Rankings:    NewRankingStore(db),
-		Problems:    NewProblemStore(db),
-		Admins:      NewAdminStore(db),
+		Contests:          NewContestStore(db),
+		Users:             NewUserStore(db),
+		Submissions:       NewSubmissionStore(db),
+		Rankings:          NewRankingStore(db),
+		Problems:          NewProblemStore(db),
+		ProblemSets:       NewProblemSetStore(db),
+		Sections:          NewSectionStore(db),
+		Invites:           NewInviteStore(db),
+		Drives:            NewDriveStore(db),
+		Interviews:        NewInterviewStore(db),
+		Teams:             NewTeamStore(db),
+		Editorials:        NewEditorialStore(db),
+		Announcements:     NewAnnouncementStore(db),
+		Clarifications:    NewClarificationStore(db),
+		Admins:            NewAdminStore(db),
+		Disqualifications: NewDisqualificationStore(db),
+		Suspensions:       NewSuspensionStore(db),
+		Audit:             NewAuditStore(db),
 	}
 }
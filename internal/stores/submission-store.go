@@ -56,7 +56,7 @@ func (s *SubmissionStore) GetSubmissionDetailsByID(ctx context.Context, id strin
 	}
 
 	const q = `
-		SELECT user_id, contest_id, problem_id, type, language, choices, status, created_at, runtime, memory
+		SELECT user_id, contest_id, problem_id, type, language, choices, status, created_at, runtime, memory, score, practice, COALESCE(team_id, ''), virtual
 		FROM submissions
 		WHERE id = $1
 	`
@@ -64,6 +64,7 @@ func (s *SubmissionStore) GetSubmissionDetailsByID(ctx context.Context, id strin
 	sub.ID = id
 
 	var rawChoices sql.NullString
+	var score sql.NullInt64
 
 	row := s.db.QueryRowContext(ctx, q, id)
 	if err := row.Scan(
@@ -77,6 +78,10 @@ func (s *SubmissionStore) GetSubmissionDetailsByID(ctx context.Context, id strin
 		&sub.CreatedAt,
 		&sub.Runtime,
 		&sub.Memory,
+		&score,
+		&sub.Practice,
+		&sub.TeamID,
+		&sub.Virtual,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.ErrNotFound
@@ -85,6 +90,11 @@ func (s *SubmissionStore) GetSubmissionDetailsByID(ctx context.Context, id strin
 		return nil, fmt.Errorf("scan submission: %w", err)
 	}
 
+	if score.Valid {
+		awarded := int(score.Int64)
+		sub.Score = &awarded
+	}
+
 	sub.Option = []int{}
 	if rawChoices.Valid && rawChoices.String != "" && rawChoices.String != "{}" {
 		choiceStr := strings.TrimSpace(strings.Trim(rawChoices.String, "{}"))
@@ -166,7 +176,7 @@ func (s *SubmissionStore) ListUserSubmissionsByProblemID(ctx context.Context, us
 	offset := page * pageSize
 
 	const q = `
-		SELECT id, contest_id, problem_id, type, language, status, created_at, runtime, memory
+		SELECT id, contest_id, problem_id, type, language, status, created_at, runtime, memory, practice
 		FROM submissions
 		WHERE user_id = $1 AND problem_id = $2
 		ORDER BY created_at DESC
@@ -194,6 +204,7 @@ func (s *SubmissionStore) ListUserSubmissionsByProblemID(ctx context.Context, us
 			&sub.CreatedAt,
 			&sub.Runtime,
 			&sub.Memory,
+			&sub.Practice,
 		); err != nil {
 			log.Printf("submission-store: failed to scan submission row: %v", err)
 			continue
@@ -209,6 +220,66 @@ func (s *SubmissionStore) ListUserSubmissionsByProblemID(ctx context.Context, us
 	return submissions, nil
 }
 
+// ListUserSubmissions lists the user's submissions across every contest, newest first
+func (s *SubmissionStore) ListUserSubmissions(ctx context.Context, userID string, page int) ([]models.Submission, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("submission store: db is not initialized")
+	}
+
+	const pageSize = 20
+	page = max(0, page)
+	offset := page * pageSize
+
+	const q = `
+		SELECT id, contest_id, problem_id, type, COALESCE(language, ''), status, created_at,
+			COALESCE(runtime, 0), COALESCE(memory, 0), score, practice, virtual
+		FROM submissions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, userID, pageSize, offset)
+	if err != nil {
+		log.Printf("submission-store: query failed: %v", err)
+		return nil, fmt.Errorf("query user submissions: %w", err)
+	}
+	defer rows.Close()
+
+	submissions := make([]models.Submission, 0)
+	for rows.Next() {
+		sub := models.Submission{UserID: userID}
+
+		if err := rows.Scan(
+			&sub.ID,
+			&sub.ContestID,
+			&sub.ProblemID,
+			&sub.Type,
+			&sub.Language,
+			&sub.Status,
+			&sub.CreatedAt,
+			&sub.Runtime,
+			&sub.Memory,
+			&sub.Score,
+			&sub.Practice,
+			&sub.Virtual,
+		); err != nil {
+			log.Printf("submission-store: failed to scan submission row: %v", err)
+			return nil, fmt.Errorf("scan submission row: %w", err)
+		}
+		submissions = append(submissions, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("submission-store: rows error: %v", err)
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return submissions, nil
+}
+
+// CreateSubmission records a submission. A graded contest submission is scored onto the rankings
+// of the user and their team in the same transaction.
 func (s *SubmissionStore) CreateSubmission(ctx context.Context, sub *models.Submission) (string, error) {
 	if s == nil || s.db == nil {
 		return "", fmt.Errorf("submission store: db is not initialized")
@@ -218,7 +289,10 @@ func (s *SubmissionStore) CreateSubmission(ctx context.Context, sub *models.Subm
 	sub.CreatedAt = time.Now().Unix()
 
 	dbType := strings.ToLower(string(sub.Type))
-	dbStatus := "pending"
+	dbStatus := models.Pending
+	if sub.Status != "" {
+		dbStatus = sub.Status
+	}
 
 	choiceStrings := make([]string, len(sub.Option))
 	for i, choice := range sub.Option {
@@ -226,15 +300,30 @@ func (s *SubmissionStore) CreateSubmission(ctx context.Context, sub *models.Subm
 	}
 	mcqChoices := fmt.Sprintf("{%s}", strings.Join(choiceStrings, ","))
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("submission-store: begin tx failed: %v", err)
+		return "", fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	scored := sub.Score != nil && !sub.Practice
+	if scored {
+		if err := lockSubmissionRankings(ctx, tx, sub); err != nil {
+			return "", err
+		}
+	}
+
 	const q = `
 		INSERT INTO 
-		submissions (id, user_id, contest_id, problem_id, type, language, choices, status, created_at, runtime, memory)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id
+		submissions (id, user_id, contest_id, problem_id, type, language, choices, status, created_at, runtime, memory, score, practice, team_id, virtual)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NULLIF($14, ''), $15)
+		RETURNING id, seq
 	`
 
 	var submissionID string
-	err := s.db.QueryRowContext(ctx, q,
+	var seq int64
+	err = tx.QueryRowContext(ctx, q,
 		sub.ID,
 		sub.UserID,
 		sub.ContestID,
@@ -246,12 +335,27 @@ func (s *SubmissionStore) CreateSubmission(ctx context.Context, sub *models.Subm
 		sub.CreatedAt,
 		sub.Runtime,
 		sub.Memory,
-	).Scan(&submissionID)
+		sub.Score,
+		sub.Practice,
+		sub.TeamID,
+		sub.Virtual,
+	).Scan(&submissionID, &seq)
 
 	if err != nil {
 		log.Printf("submission-store: failed to insert submission: %v", err)
 		return "", fmt.Errorf("insert submission: %w", err)
 	}
 
+	if scored {
+		if err := applySubmissionScore(ctx, tx, sub, seq); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("submission-store: commit failed: %v", err)
+		return "", fmt.Errorf("commit submission: %w", err)
+	}
+
 	return submissionID, nil
 }
@@ -0,0 +1,166 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"app/internal/models/dto"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+type SuspensionStore struct {
+	db *sql.DB
+}
+
+func NewSuspensionStore(db *sql.DB) *SuspensionStore {
+	return &SuspensionStore{
+		db: db,
+	}
+}
+
+const suspensionColumns = `id, user_id, contest_id, reason, expires_at, created_by, created_at, lifted_at, lifted_by, lift_reason`
+
+func scanSuspension(row rowScanner, s *models.Suspension) error {
+	var contestID, createdBy, liftedBy sql.NullString
+	var expiresAt, liftedAt sql.NullInt64
+
+	if err := row.Scan(&s.ID, &s.UserID, &contestID, &s.Reason, &expiresAt, &createdBy, &s.CreatedAt, &liftedAt, &liftedBy, &s.LiftReason); err != nil {
+		return err
+	}
+
+	s.ContestID = contestID.String
+	s.ExpiresAt = expiresAt.Int64
+	s.CreatedBy = createdBy.String
+	s.LiftedAt = liftedAt.Int64
+	s.LiftedBy = liftedBy.String
+	return nil
+}
+
+// ListSuspensions lists suspensions newest first, lifted and expired ones included. Empty filters match everything.
+func (s *SuspensionStore) ListSuspensions(ctx context.Context, req *dto.ListSuspensionsRequest) ([]models.Suspension, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("suspension store: db is not initialized")
+	}
+
+	const pageSize = 20
+
+	offset := req.Page * pageSize
+
+	const q = `
+		SELECT ` + suspensionColumns + `
+		FROM user_suspensions
+		WHERE ($1 = '' OR user_id = $1) AND ($2 = '' OR contest_id = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := s.db.QueryContext(ctx, q, req.UserID, req.ContestID, pageSize, offset)
+	if err != nil {
+		log.Printf("suspension-store: query failed: %v", err)
+		return nil, fmt.Errorf("query suspensions: %w", err)
+	}
+	defer rows.Close()
+
+	suspensions := make([]models.Suspension, 0)
+	for rows.Next() {
+		var sus models.Suspension
+		if err := scanSuspension(rows, &sus); err != nil {
+			log.Printf("suspension-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan suspension row: %w", err)
+		}
+		suspensions = append(suspensions, sus)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("suspension-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return suspensions, nil
+}
+
+// GetActiveSuspension returns a suspension of the user that applies to the contest, global ones first,
+// or nil if there is none. An empty contestID only matches global suspensions.
+func (s *SuspensionStore) GetActiveSuspension(ctx context.Context, userID string, contestID string, now int64) (*models.Suspension, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("suspension store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + suspensionColumns + `
+		FROM user_suspensions
+		WHERE user_id = $1
+			AND (contest_id IS NULL OR contest_id = $2)
+			AND lifted_at IS NULL
+			AND (expires_at IS NULL OR expires_at > $3)
+		ORDER BY contest_id NULLS FIRST, created_at DESC
+		LIMIT 1
+	`
+
+	var sus models.Suspension
+	if err := scanSuspension(s.db.QueryRowContext(ctx, q, userID, contestID, now), &sus); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("suspension-store: query failed: %v", err)
+		return nil, fmt.Errorf("query suspension: %w", err)
+	}
+
+	return &sus, nil
+}
+
+func (s *SuspensionStore) CreateSuspension(ctx context.Context, sus *models.Suspension) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("suspension store: db is not initialized")
+	}
+
+	const q = `
+		INSERT INTO user_suspensions (id, user_id, contest_id, reason, expires_at, created_by, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, 0), NULLIF($6, ''), $7)
+	`
+
+	_, err := s.db.ExecContext(ctx, q, sus.ID, sus.UserID, sus.ContestID, sus.Reason, sus.ExpiresAt, sus.CreatedBy, sus.CreatedAt)
+	if err != nil {
+		log.Printf("suspension-store: insert failed: %v", err)
+		return fmt.Errorf("insert suspension: %w", err)
+	}
+
+	return nil
+}
+
+// LiftSuspension ends a suspension early, keeping it for the record
+func (s *SuspensionStore) LiftSuspension(ctx context.Context, sus *models.Suspension) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("suspension store: db is not initialized")
+	}
+
+	const q = `
+		UPDATE user_suspensions
+		SET lifted_at = $2, lifted_by = NULLIF($3, ''), lift_reason = $4
+		WHERE id = $1 AND lifted_at IS NULL
+		RETURNING ` + suspensionColumns
+
+	err := scanSuspension(s.db.QueryRowContext(ctx, q, sus.ID, sus.LiftedAt, sus.LiftedBy, sus.LiftReason), sus)
+	if err == nil {
+		return nil
+	}
+
+	if err != sql.ErrNoRows {
+		log.Printf("suspension-store: update failed: %v", err)
+		return fmt.Errorf("lift suspension: %w", err)
+	}
+
+	var exists bool
+	const existsQ = `SELECT EXISTS(SELECT 1 FROM user_suspensions WHERE id = $1)`
+	if err := s.db.QueryRowContext(ctx, existsQ, sus.ID).Scan(&exists); err != nil {
+		log.Printf("suspension-store: query failed: %v", err)
+		return fmt.Errorf("query suspension: %w", err)
+	}
+
+	if !exists {
+		return common.SuspensionNotFoundError
+	}
+	return common.SuspensionAlreadyLiftedError
+}
@@ -0,0 +1,468 @@
+package stores
+
+import (
+	"app/internal/common"
+	"app/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+type TeamStore struct {
+	db *sql.DB
+}
+
+func NewTeamStore(db *sql.DB) *TeamStore {
+	return &TeamStore{
+		db: db,
+	}
+}
+
+// teamColumns reads a team aliased t
+const teamColumns = `t.id, t.contest_id, t.name, t.invite_code, t.leader_id, t.created_at, COALESCE(t.registered_at, 0)`
+
+func scanTeam(row rowScanner, t *models.Team) error {
+	return row.Scan(&t.ID, &t.ContestID, &t.Name, &t.InviteCode, &t.LeaderID, &t.CreatedAt, &t.RegisteredAt)
+}
+
+// CreateTeam creates a team with its leader as the only member
+func (s *TeamStore) CreateTeam(ctx context.Context, t *models.Team) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("team store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("team-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const teamQ = `
+		INSERT INTO teams (id, contest_id, name, invite_code, leader_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING
+	`
+
+	res, err := tx.ExecContext(ctx, teamQ, t.ID, t.ContestID, t.Name, t.InviteCode, t.LeaderID, t.CreatedAt)
+	if err != nil {
+		log.Printf("team-store: insert failed: %v", err)
+		return fmt.Errorf("insert team: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("team-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.TeamNameTakenError
+	}
+
+	if err := insertTeamMember(ctx, tx, t.ID, t.ContestID, t.LeaderID, t.CreatedAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("team-store: commit failed: %v", err)
+		return fmt.Errorf("commit team: %w", err)
+	}
+
+	t.Members = []models.TeamMember{{UserID: t.LeaderID, JoinedAt: t.CreatedAt}}
+	return nil
+}
+
+func insertTeamMember(ctx context.Context, tx *sql.Tx, teamID string, contestID string, userID string, joinedAt int64) error {
+	const q = `
+		INSERT INTO team_members (team_id, contest_id, user_id, joined_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (contest_id, user_id) DO NOTHING
+	`
+
+	res, err := tx.ExecContext(ctx, q, teamID, contestID, userID, joinedAt)
+	if err != nil {
+		log.Printf("team-store: insert failed: %v", err)
+		return fmt.Errorf("insert team member: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("team-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.AlreadyInTeamError
+	}
+
+	return nil
+}
+
+// GetUserTeam returns the team of the user in a contest with its members
+func (s *TeamStore) GetUserTeam(ctx context.Context, contestID string, userID string) (*models.Team, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("team store: db is not initialized")
+	}
+
+	const q = `
+		SELECT ` + teamColumns + `
+		FROM teams t
+		JOIN team_members m ON m.team_id = t.id
+		WHERE m.contest_id = $1 AND m.user_id = $2
+	`
+
+	var t models.Team
+	if err := scanTeam(s.db.QueryRowContext(ctx, q, contestID, userID), &t); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.TeamNotFoundError
+		}
+		log.Printf("team-store: query failed: %v", err)
+		return nil, fmt.Errorf("query team: %w", err)
+	}
+
+	const membersQ = `
+		SELECT user_id, joined_at
+		FROM team_members
+		WHERE team_id = $1
+		ORDER BY joined_at ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, membersQ, t.ID)
+	if err != nil {
+		log.Printf("team-store: query failed: %v", err)
+		return nil, fmt.Errorf("query team members: %w", err)
+	}
+	defer rows.Close()
+
+	t.Members = make([]models.TeamMember, 0)
+	for rows.Next() {
+		var m models.TeamMember
+		if err := rows.Scan(&m.UserID, &m.JoinedAt); err != nil {
+			log.Printf("team-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan team member row: %w", err)
+		}
+		t.Members = append(t.Members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("team-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return &t, nil
+}
+
+// lockTeam locks a team of the contest against concurrent membership changes and registration
+func lockTeam(ctx context.Context, tx *sql.Tx, query string, args ...any) (*models.Team, error) {
+	var t models.Team
+	if err := scanTeam(tx.QueryRowContext(ctx, query, args...), &t); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.TeamNotFoundError
+		}
+		log.Printf("team-store: query failed: %v", err)
+		return nil, fmt.Errorf("lock team: %w", err)
+	}
+	return &t, nil
+}
+
+// JoinTeam adds the user to the team with the invite code while it has fewer than maxSize members
+// and is not registered
+func (s *TeamStore) JoinTeam(ctx context.Context, contestID string, inviteCode string, userID string, maxSize int, joinedAt int64) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("team store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("team-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const lockQ = `
+		SELECT ` + teamColumns + `
+		FROM teams t
+		WHERE t.contest_id = $1 AND t.invite_code = $2
+		FOR UPDATE
+	`
+
+	team, err := lockTeam(ctx, tx, lockQ, contestID, inviteCode)
+	if err != nil {
+		return err
+	}
+
+	if team.IsRegistered() {
+		return common.TeamAlreadyRegisteredError
+	}
+
+	const countQ = `SELECT COUNT(*) FROM team_members WHERE team_id = $1`
+
+	var members int
+	if err := tx.QueryRowContext(ctx, countQ, team.ID).Scan(&members); err != nil {
+		log.Printf("team-store: query failed: %v", err)
+		return fmt.Errorf("count team members: %w", err)
+	}
+
+	if members >= maxSize {
+		return common.TeamFullError
+	}
+
+	if err := insertTeamMember(ctx, tx, team.ID, contestID, userID, joinedAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("team-store: commit failed: %v", err)
+		return fmt.Errorf("commit team member: %w", err)
+	}
+
+	return nil
+}
+
+// LeaveTeam removes the user from their unregistered team. The team is disbanded when its leader leaves.
+func (s *TeamStore) LeaveTeam(ctx context.Context, contestID string, userID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("team store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("team-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const lockQ = `
+		SELECT ` + teamColumns + `
+		FROM teams t
+		JOIN team_members m ON m.team_id = t.id
+		WHERE m.contest_id = $1 AND m.user_id = $2
+		FOR UPDATE OF t
+	`
+
+	team, err := lockTeam(ctx, tx, lockQ, contestID, userID)
+	if err != nil {
+		return err
+	}
+
+	if team.IsRegistered() {
+		return common.TeamAlreadyRegisteredError
+	}
+
+	if team.LeaderID == userID {
+		_, err = tx.ExecContext(ctx, `DELETE FROM teams WHERE id = $1`, team.ID)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM team_members WHERE team_id = $1 AND user_id = $2`, team.ID, userID)
+	}
+	if err != nil {
+		log.Printf("team-store: delete failed: %v", err)
+		return fmt.Errorf("leave team: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("team-store: commit failed: %v", err)
+		return fmt.Errorf("commit team: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterTeam registers every member of the team for the contest, as long as the contest has seats for all of them
+func (s *TeamStore) RegisterTeam(ctx context.Context, contestID string, teamID string, registeredAt int64) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("team store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("team-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	maxParticipants, err := lockContestSeats(ctx, tx, contestID)
+	if err != nil {
+		return err
+	}
+
+	const lockQ = `
+		SELECT ` + teamColumns + `
+		FROM teams t
+		WHERE t.id = $1 AND t.contest_id = $2
+		FOR UPDATE
+	`
+
+	team, err := lockTeam(ctx, tx, lockQ, teamID, contestID)
+	if err != nil {
+		return err
+	}
+
+	if team.IsRegistered() {
+		return common.TeamAlreadyRegisteredError
+	}
+
+	const seatsQ = `
+		SELECT
+			(SELECT COUNT(*) FROM contest_registrations WHERE contest_id = $1),
+			(SELECT COUNT(*) FROM team_members WHERE team_id = $2)
+	`
+
+	var registered, members int
+	if err := tx.QueryRowContext(ctx, seatsQ, contestID, teamID).Scan(&registered, &members); err != nil {
+		log.Printf("team-store: query failed: %v", err)
+		return fmt.Errorf("count seats: %w", err)
+	}
+
+	if maxParticipants > 0 && registered+members > maxParticipants {
+		return common.ContestFullError
+	}
+
+	const registerQ = `
+		INSERT INTO contest_registrations (contest_id, user_id, registered_at)
+		SELECT contest_id, user_id, $2
+		FROM team_members
+		WHERE team_id = $1
+		ON CONFLICT (contest_id, user_id) DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, registerQ, teamID, registeredAt); err != nil {
+		log.Printf("team-store: insert failed: %v", err)
+		return fmt.Errorf("register team members: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE teams SET registered_at = $2 WHERE id = $1`, teamID, registeredAt); err != nil {
+		log.Printf("team-store: update failed: %v", err)
+		return fmt.Errorf("register team: %w", err)
+	}
+
+	// The team starts on the leaderboard, with its flags taken from its members
+	const rankingQ = `
+		INSERT INTO team_rankings (contest_id, team_id)
+		VALUES ($1, $2)
+		ON CONFLICT (contest_id, team_id) DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, rankingQ, contestID, teamID); err != nil {
+		log.Printf("team-store: insert failed: %v", err)
+		return fmt.Errorf("create team ranking: %w", err)
+	}
+
+	if err := updateTeamRankingFlags(ctx, tx, contestID, team.LeaderID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("team-store: commit failed: %v", err)
+		return fmt.Errorf("commit team registration: %w", err)
+	}
+
+	return nil
+}
+
+// UnregisterTeam removes the registrations of every member, unless one of them already started their attempt
+func (s *TeamStore) UnregisterTeam(ctx context.Context, contestID string, teamID string) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("team store: db is not initialized")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("team-store: begin tx failed: %v", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockContestSeats(ctx, tx, contestID); err != nil {
+		return err
+	}
+
+	const lockQ = `
+		SELECT ` + teamColumns + `
+		FROM teams t
+		WHERE t.id = $1 AND t.contest_id = $2
+		FOR UPDATE
+	`
+
+	team, err := lockTeam(ctx, tx, lockQ, teamID, contestID)
+	if err != nil {
+		return err
+	}
+
+	if !team.IsRegistered() {
+		return common.TeamNotRegisteredError
+	}
+
+	const startedQ = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM contest_registrations r
+			JOIN team_members m ON m.contest_id = r.contest_id AND m.user_id = r.user_id
+			WHERE m.team_id = $1 AND r.started_at IS NOT NULL
+		)
+	`
+
+	var started bool
+	if err := tx.QueryRowContext(ctx, startedQ, teamID).Scan(&started); err != nil {
+		log.Printf("team-store: query failed: %v", err)
+		return fmt.Errorf("query attempts: %w", err)
+	}
+
+	if started {
+		return common.AttemptAlreadyStartedError
+	}
+
+	const unregisterQ = `
+		DELETE FROM contest_registrations r
+		USING team_members m
+		WHERE m.team_id = $1 AND r.contest_id = m.contest_id AND r.user_id = m.user_id
+	`
+
+	if _, err := tx.ExecContext(ctx, unregisterQ, teamID); err != nil {
+		log.Printf("team-store: delete failed: %v", err)
+		return fmt.Errorf("unregister team members: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE teams SET registered_at = NULL WHERE id = $1`, teamID); err != nil {
+		log.Printf("team-store: update failed: %v", err)
+		return fmt.Errorf("unregister team: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_rankings WHERE contest_id = $1 AND team_id = $2`, contestID, teamID); err != nil {
+		log.Printf("team-store: delete failed: %v", err)
+		return fmt.Errorf("delete team ranking: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("team-store: commit failed: %v", err)
+		return fmt.Errorf("commit team registration: %w", err)
+	}
+
+	return nil
+}
+
+// GetRegisteredTeamID returns the registered team of the user in a contest, or "" if they have none
+func (s *TeamStore) GetRegisteredTeamID(ctx context.Context, contestID string, userID string) (string, error) {
+	if s == nil || s.db == nil {
+		return "", fmt.Errorf("team store: db is not initialized")
+	}
+
+	const q = `
+		SELECT t.id
+		FROM teams t
+		JOIN team_members m ON m.team_id = t.id
+		WHERE m.contest_id = $1 AND m.user_id = $2 AND t.registered_at IS NOT NULL
+	`
+
+	var teamID string
+	if err := s.db.QueryRowContext(ctx, q, contestID, userID).Scan(&teamID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		log.Printf("team-store: query failed: %v", err)
+		return "", fmt.Errorf("query team: %w", err)
+	}
+
+	return teamID, nil
+}
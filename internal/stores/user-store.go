@@ -108,3 +108,201 @@ func (us *UserStore) UpdateUserProfile(ctx context.Context, userID string, req *
 
 	return nil
 }
+
+const adminUserColumns = `id, name, email, usn, COALESCE(mobile_number, ''), current_year, department, banned_at, ban_reason, banned_by`
+
+func scanAdminUser(row rowScanner, u *dto.AdminUser) error {
+	var bannedAt sql.NullInt64
+	var bannedBy sql.NullString
+
+	if err := row.Scan(&u.ID, &u.Name, &u.Email, &u.USN, &u.MobileNumber, &u.CurrentYear, &u.Department, &bannedAt, &u.BanReason, &bannedBy); err != nil {
+		return err
+	}
+
+	u.User.ID = u.ID
+	u.BannedAt = bannedAt.Int64
+	u.BannedBy = bannedBy.String
+	return nil
+}
+
+// SearchUsers lists the users matching every given filter, ordered by name
+func (us *UserStore) SearchUsers(ctx context.Context, req *dto.AdminListUsersRequest) ([]dto.AdminUser, error) {
+	if us == nil || us.db == nil {
+		return nil, fmt.Errorf("user store: db is not initialized")
+	}
+
+	const pageSize = 20
+
+	offset := req.Page * pageSize
+
+	const q = `
+		SELECT ` + adminUserColumns + `
+		FROM users
+		WHERE ($1 = '' OR name ILIKE '%' || $1 || '%' OR usn ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%')
+			AND ($2 = '' OR name ILIKE '%' || $2 || '%')
+			AND ($3 = '' OR usn ILIKE '%' || $3 || '%')
+			AND ($4 = '' OR email ILIKE '%' || $4 || '%')
+			AND ($5 = '' OR LOWER(department) = LOWER($5))
+			AND ($6 = 0 OR current_year = $6)
+			AND ($7 = '' OR ($7 = 'banned') = (banned_at IS NOT NULL))
+		ORDER BY name, id
+		LIMIT $8 OFFSET $9
+	`
+
+	rows, err := us.db.QueryContext(ctx, q, req.Query, req.Name, req.USN, req.Email, req.Department, req.CurrentYear, req.Status, pageSize, offset)
+	if err != nil {
+		log.Printf("user-store: query failed: %v", err)
+		return nil, fmt.Errorf("query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]dto.AdminUser, 0)
+	for rows.Next() {
+		var u dto.AdminUser
+		if err := scanAdminUser(rows, &u); err != nil {
+			log.Printf("user-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan user row: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("user-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return users, nil
+}
+
+func (us *UserStore) GetAdminUser(ctx context.Context, userID string) (*dto.AdminUser, error) {
+	if us == nil || us.db == nil {
+		return nil, fmt.Errorf("user store: db is not initialized")
+	}
+
+	const q = `SELECT ` + adminUserColumns + ` FROM users WHERE id = $1`
+
+	var u dto.AdminUser
+	if err := scanAdminUser(us.db.QueryRowContext(ctx, q, userID), &u); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.UserNotFoundError
+		}
+		log.Printf("user-store: row error %v", err)
+		return nil, fmt.Errorf("row error: %w", err)
+	}
+
+	return &u, nil
+}
+
+// ListUserRegistrations lists the contests the user registered for with their ranking, newest first
+func (us *UserStore) ListUserRegistrations(ctx context.Context, userID string) ([]dto.UserRegistration, error) {
+	if us == nil || us.db == nil {
+		return nil, fmt.Errorf("user store: db is not initialized")
+	}
+
+	const q = `
+		SELECT c.id, c.name, r.registered_at, rk.score,
+			COALESCE(rk.hidden, FALSE), COALESCE(rk.disqualified, FALSE), COALESCE(rk.shortlisted, FALSE)
+		FROM contest_registrations r
+		JOIN contests c ON c.id = r.contest_id
+		LEFT JOIN rankings rk ON rk.contest_id = r.contest_id AND rk.user_id = r.user_id
+		WHERE r.user_id = $1
+		ORDER BY r.registered_at DESC
+	`
+
+	rows, err := us.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		log.Printf("user-store: query failed: %v", err)
+		return nil, fmt.Errorf("query registrations: %w", err)
+	}
+	defer rows.Close()
+
+	registrations := make([]dto.UserRegistration, 0)
+	for rows.Next() {
+		var r dto.UserRegistration
+		if err := rows.Scan(&r.ContestID, &r.ContestName, &r.RegisteredAt, &r.Score, &r.Hidden, &r.Disqualified, &r.Shortlisted); err != nil {
+			log.Printf("user-store: row scan failed: %v", err)
+			return nil, fmt.Errorf("scan registration row: %w", err)
+		}
+		registrations = append(registrations, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("user-store: rows error: %v", err)
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return registrations, nil
+}
+
+// AdminUpdateUser updates every profile field of a user, including the USN and year
+func (us *UserStore) AdminUpdateUser(ctx context.Context, userID string, req *dto.AdminUpdateUserRequest) error {
+	if us == nil || us.db == nil {
+		return fmt.Errorf("user store: db is not initialized")
+	}
+
+	var taken bool
+	const takenQ = `SELECT EXISTS(SELECT 1 FROM users WHERE usn = $1 AND id <> $2)`
+	if err := us.db.QueryRowContext(ctx, takenQ, req.USN, userID).Scan(&taken); err != nil {
+		log.Printf("user-store: query failed: %v", err)
+		return fmt.Errorf("query usn: %w", err)
+	}
+
+	if taken {
+		return common.USNTakenError
+	}
+
+	const q = `
+		UPDATE users
+		SET name = $2, usn = $3, mobile_number = $4, current_year = $5, department = $6
+		WHERE id = $1
+	`
+
+	res, err := us.db.ExecContext(ctx, q, userID, req.Name, req.USN, req.MobileNumber, req.CurrentYear, req.Department)
+	if err != nil {
+		log.Printf("user-store: update error %v", err)
+		return fmt.Errorf("update error: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("user-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.UserNotFoundError
+	}
+
+	return nil
+}
+
+// SetBan bans the user, or lifts their ban when bannedAt is zero
+func (us *UserStore) SetBan(ctx context.Context, userID string, bannedAt int64, reason string, adminID string) error {
+	if us == nil || us.db == nil {
+		return fmt.Errorf("user store: db is not initialized")
+	}
+
+	const q = `
+		UPDATE users
+		SET banned_at = NULLIF($2, 0), ban_reason = $3, banned_by = NULLIF($4, '')
+		WHERE id = $1
+	`
+
+	res, err := us.db.ExecContext(ctx, q, userID, bannedAt, reason, adminID)
+	if err != nil {
+		log.Printf("user-store: update error %v", err)
+		return fmt.Errorf("update error: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		log.Printf("user-store: rows error %v", err)
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if affected == 0 {
+		return common.UserNotFoundError
+	}
+
+	return nil
+}